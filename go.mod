@@ -5,6 +5,7 @@ go 1.19
 require (
 	github.com/r3labs/diff/v3 v3.0.1
 	github.com/stretchr/testify v1.8.2
+	golang.org/x/crypto v0.6.0
 )
 
 require (
@@ -36,7 +37,6 @@ require (
 	github.com/spf13/cast v1.5.0 // indirect
 	github.com/vmihailenco/msgpack/v5 v5.3.5 // indirect
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
-	golang.org/x/crypto v0.6.0 // indirect
 	golang.org/x/mod v0.9.0 // indirect
 	google.golang.org/genproto v0.0.0-20230303212802-e74f57abe488 // indirect
 	google.golang.org/grpc v1.53.0 // indirect
@@ -13,6 +13,9 @@ import (
 
 type Proxmox struct {
 	client *proxmox.DefaultApiService
+	config *proxmox.Configuration
+	ids    *vmIdAllocator
+	ssh    *SSHConfig
 	IsRoot bool
 }
 
@@ -22,6 +25,7 @@ type ClientConfig struct {
 	SkipVerify bool
 	Username   string
 	Password   string
+	SSH        *SSHConfig
 }
 
 func New(c ClientConfig) (*Proxmox, error) {
@@ -55,6 +59,9 @@ func newTokenClient(c ClientConfig, cfg *proxmox.Configuration) (*Proxmox, error
 	client := proxmox.NewAPIClient(cfg)
 	return &Proxmox{
 		client: client.DefaultApi,
+		config: client.GetConfig(),
+		ids:    newVmIdAllocator(),
+		ssh:    c.SSH,
 		IsRoot: false,
 	}, nil
 }
@@ -63,6 +70,9 @@ func newBasicAuthClient(c ClientConfig, cfg *proxmox.Configuration) (*Proxmox, e
 	client := proxmox.NewAPIClient(cfg)
 	p := &Proxmox{
 		client: client.DefaultApi,
+		config: client.GetConfig(),
+		ids:    newVmIdAllocator(),
+		ssh:    c.SSH,
 		IsRoot: c.Username == "root@pam",
 	}
 
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/awlsring/proxmox-go/proxmox"
 	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
 )
 
 type CloneVirtualMachineInput struct {
@@ -71,3 +74,70 @@ func (c *Proxmox) CreateVirtualMachineIso(ctx context.Context, input *CreateVirt
 	}
 	return nil
 }
+
+type RestoreVirtualMachineInput struct {
+	Node           string
+	VmId           int
+	Archive        string
+	Storage        *string
+	Unique         bool
+	BandwidthLimit *int64
+	ResourcePool   *string
+}
+
+// starts restoring the backup archive into a new virtual machine and returns the task id
+func (c *Proxmox) RestoreVirtualMachine(ctx context.Context, input *RestoreVirtualMachineInput) (string, error) {
+	path := fmt.Sprintf("/nodes/%s/qemu", input.Node)
+
+	body := map[string]interface{}{
+		"vmid":    input.VmId,
+		"archive": input.Archive,
+	}
+	if input.Storage != nil {
+		body["storage"] = *input.Storage
+	}
+	if input.Unique {
+		body["unique"] = 1
+	}
+	if input.BandwidthLimit != nil {
+		body["bwlimit"] = *input.BandwidthLimit
+	}
+	if input.ResourcePool != nil {
+		body["pool"] = *input.ResourcePool
+	}
+
+	var upid string
+	err := c.request(ctx, http.MethodPost, path, nil, body, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
+
+type CreateVirtualMachinePxeInput struct {
+	Node         string
+	VmId         int
+	BootDevice   string
+	Name         *string
+	Description  *string
+	ResourcePool *string
+}
+
+func (c *Proxmox) CreateVirtualMachinePxe(ctx context.Context, input *CreateVirtualMachinePxeInput) error {
+	vmId := strconv.Itoa(input.VmId)
+	content := proxmox.CreateVirtualMachineRequestContent{
+		Vmid:        vmId,
+		Name:        input.Name,
+		Description: input.Description,
+		Boot:        vm.FormBootOrderString([]string{input.BootDevice}),
+		Pool:        input.ResourcePool,
+	}
+	request := c.client.CreateVirtualMachine(ctx, input.Node)
+	request = request.CreateVirtualMachineRequestContent(content)
+	_, h, err := c.client.CreateVirtualMachineExecute(request)
+	if err != nil {
+		return errors.ApiError(h, err)
+	}
+	return nil
+}
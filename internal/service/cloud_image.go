@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+)
+
+type DownloadCloudImageInput struct {
+	Node              string
+	Storage           string
+	Url               string
+	FileName          string
+	Checksum          *string
+	ChecksumAlgorithm *string
+}
+
+type CreateVirtualMachineFromImageInput struct {
+	Node         string
+	VmId         int
+	Disk         string
+	Storage      string
+	Source       string
+	Name         *string
+	Description  *string
+	ResourcePool *string
+}
+
+type storageVolume struct {
+	VolumeId string `json:"volid"`
+}
+
+// starts downloading the image to the storage as iso content and returns the task id
+func (c *Proxmox) DownloadCloudImage(ctx context.Context, input *DownloadCloudImageInput) (string, error) {
+	content := proxmox.DownloadFromUrlToStorageRequestContent{
+		Content:  proxmox.UPLOADCONTENTTYPE_ISO,
+		Filename: input.FileName,
+		Url:      input.Url,
+		Checksum: input.Checksum,
+	}
+	if input.ChecksumAlgorithm != nil {
+		algorithm := proxmox.ChecksumAlgorithm(*input.ChecksumAlgorithm)
+		content.ChecksumAlgorithm = &algorithm
+	}
+
+	request := c.client.DownloadFromUrlToStorage(ctx, input.Node, input.Storage)
+	request = request.DownloadFromUrlToStorageRequestContent(content)
+	resp, h, err := c.client.DownloadFromUrlToStorageExecute(request)
+	if err != nil {
+		return "", errors.ApiError(h, err)
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Proxmox) StorageVolumeExists(ctx context.Context, node string, storage string, content string, volid string) (bool, error) {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content", node, storage)
+	query := url.Values{}
+	query.Set("content", content)
+
+	var volumes []storageVolume
+	err := c.request(ctx, http.MethodGet, path, query, nil, &volumes)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range volumes {
+		if v.VolumeId == volid {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// removes the volume from the storage, the returned task id is nil when the storage deletes it synchronously
+func (c *Proxmox) DeleteStorageVolume(ctx context.Context, node string, storage string, volid string) (*string, error) {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, url.PathEscape(volid))
+
+	var upid *string
+	err := c.request(ctx, http.MethodDelete, path, nil, nil, &upid)
+	if err != nil {
+		return nil, err
+	}
+
+	return upid, nil
+}
+
+func FormImportDiskString(storage string, source string) string {
+	return fmt.Sprintf("%s:0,import-from=%s", storage, source)
+}
+
+// starts creating a virtual machine with the image imported as a disk and returns the task id
+func (c *Proxmox) CreateVirtualMachineFromImage(ctx context.Context, input *CreateVirtualMachineFromImageInput) (string, error) {
+	path := fmt.Sprintf("/nodes/%s/qemu", input.Node)
+
+	body := map[string]interface{}{
+		"vmid":     input.VmId,
+		input.Disk: FormImportDiskString(input.Storage, input.Source),
+	}
+	if input.Name != nil {
+		body["name"] = *input.Name
+	}
+	if input.Description != nil {
+		body["description"] = *input.Description
+	}
+	if input.ResourcePool != nil {
+		body["pool"] = *input.ResourcePool
+	}
+
+	var upid string
+	err := c.request(ctx, http.MethodPost, path, nil, body, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
@@ -21,7 +21,15 @@ type ConfigureVirtualMachineInput struct {
 	Bios              *proxmox.VirtualMachineBios                      `json:"bios,omitempty"`
 	CPU               *ConfigureVirtualMachineCpuOptions               `json:"cpu,omitempty"`
 	Disks             []ConfigureVirtualMachineDiskOptions             `json:"disks,omitempty"`
+	EfiDisk           *ConfigureVirtualMachineEfiDiskOptions           `json:"efiDisk,omitempty"`
+	TpmState          *ConfigureVirtualMachineTpmStateOptions          `json:"tpmState,omitempty"`
 	PCIDevices        []ConfigureVirtualPciDeviceOptions               `json:"pciDevices,omitempty"`
+	SerialDevices     []ConfigureVirtualMachineSerialDeviceOptions     `json:"serialDevices,omitempty"`
+	USBDevices        []ConfigureVirtualMachineUSBDeviceOptions        `json:"usbDevices,omitempty"`
+	Display           *ConfigureVirtualMachineDisplayOptions           `json:"display,omitempty"`
+	Rng               *ConfigureVirtualMachineRngOptions               `json:"rng,omitempty"`
+	Watchdog          *ConfigureVirtualMachineWatchdogOptions          `json:"watchdog,omitempty"`
+	Audio             *ConfigureVirtualMachineAudioOptions             `json:"audio,omitempty"`
 	NetworkInterfaces []ConfigureVirtualMachineNetworkInterfaceOptions `json:"networkInterfaces,omitempty"`
 	Memory            *ConfigureVirtualMachineMemoryOptions            `json:"memory,omitempty"`
 	CloudInit         *ConfigureVirtualMachineCloudInitOptions         `json:"cloudInit,omitempty"`
@@ -30,6 +38,8 @@ type ConfigureVirtualMachineInput struct {
 	MachineType       *string                                          `json:"machineType,omitempty"`
 	KVMArguments      *string                                          `json:"kvmArguments,omitempty"`
 	KeyboardLayout    *proxmox.VirtualMachineKeyboard                  `json:"keyboardLayout,omitempty"`
+	Hotplug           []string                                         `json:"hotplug,omitempty"`
+	BootOrder         []string                                         `json:"bootOrder,omitempty"`
 }
 
 type ConfigureVirtualMachineAgentOptions struct {
@@ -39,11 +49,73 @@ type ConfigureVirtualMachineAgentOptions struct {
 }
 
 type ConfigureVirtualMachineCpuOptions struct {
-	Architecture *string `json:"architecture,omitempty"`
-	Cores        *int    `json:"cores,omitempty"`
-	Sockets      *int    `json:"sockets,omitempty"`
-	EmulatedType *string `json:"emulatedType,omitempty"`
-	CpuUnits     *int64  `json:"cpuUnits,omitempty"`
+	Architecture *string                                  `json:"architecture,omitempty"`
+	Cores        *int                                     `json:"cores,omitempty"`
+	Sockets      *int                                     `json:"sockets,omitempty"`
+	EmulatedType *string                                  `json:"emulatedType,omitempty"`
+	CpuUnits     *int64                                   `json:"cpuUnits,omitempty"`
+	Limit        *float64                                 `json:"limit,omitempty"`
+	VCpus        *int                                     `json:"vcpus,omitempty"`
+	Affinity     *string                                  `json:"affinity,omitempty"`
+	Flags        []string                                 `json:"flags,omitempty"`
+	Hidden       bool                                     `json:"hidden"`
+	HvVendorId   *string                                  `json:"hvVendorId,omitempty"`
+	Numa         bool                                     `json:"numa"`
+	NumaNodes    []ConfigureVirtualMachineNumaNodeOptions `json:"numaNodes,omitempty"`
+}
+
+type ConfigureVirtualMachineNumaNodeOptions struct {
+	Position  int     `json:"position"`
+	Cpus      string  `json:"cpus"`
+	Memory    *int64  `json:"memory,omitempty"`
+	HostNodes *string `json:"hostNodes,omitempty"`
+	Policy    *string `json:"policy,omitempty"`
+}
+
+type ConfigureVirtualMachineSerialDeviceOptions struct {
+	Position int    `json:"position"`
+	Type     string `json:"type"`
+}
+
+type ConfigureVirtualMachineUSBDeviceOptions struct {
+	Position int     `json:"position"`
+	Host     *string `json:"host,omitempty"`
+	Mapping  *string `json:"mapping,omitempty"`
+	USB3     bool    `json:"usb3"`
+}
+
+type ConfigureVirtualMachineDisplayOptions struct {
+	Type                string  `json:"type"`
+	Memory              *int    `json:"memory,omitempty"`
+	SpiceFolderSharing  bool    `json:"spiceFolderSharing"`
+	SpiceVideoStreaming *string `json:"spiceVideoStreaming,omitempty"`
+}
+
+type ConfigureVirtualMachineRngOptions struct {
+	Source   string `json:"source"`
+	MaxBytes int64  `json:"maxBytes"`
+	Period   int64  `json:"period"`
+}
+
+type ConfigureVirtualMachineWatchdogOptions struct {
+	Model  string  `json:"model"`
+	Action *string `json:"action,omitempty"`
+}
+
+type ConfigureVirtualMachineAudioOptions struct {
+	Device string `json:"device"`
+	Driver string `json:"driver"`
+}
+
+type ConfigureVirtualMachineEfiDiskOptions struct {
+	Storage         string `json:"storage"`
+	EfiType         string `json:"efiType"`
+	PreEnrolledKeys bool   `json:"preEnrolledKeys"`
+}
+
+type ConfigureVirtualMachineTpmStateOptions struct {
+	Storage string `json:"storage"`
+	Version string `json:"version"`
 }
 
 type ConfigureVirtualMachineDiskOptions struct {
@@ -72,6 +144,10 @@ type ConfigureVirtualPciDeviceOptions struct {
 	DeviceId   *string `json:"deviceId,omitempty"`
 	PCIe       bool    `json:"pcie,omitempty"`
 	Mdev       *string `json:"mdev,omitempty"`
+	ROMBAR     bool    `json:"rombar"`
+	ROMFile    *string `json:"romFile,omitempty"`
+	PrimaryGPU bool    `json:"primaryGpu,omitempty"`
+	Position   int     `json:"position"`
 }
 
 type ConfigureVirtualMachineNetworkInterfaceOptions struct {
@@ -87,15 +163,17 @@ type ConfigureVirtualMachineNetworkInterfaceOptions struct {
 }
 
 type ConfigureVirtualMachineMemoryOptions struct {
-	Dedicated *int64 `json:"dedicated,omitempty"`
-	Shared    *int64 `json:"shared,omitempty"`
-	Floating  *int64 `json:"floating,omitempty"`
+	Dedicated *int64  `json:"dedicated,omitempty"`
+	Shared    *int64  `json:"shared,omitempty"`
+	Floating  *int64  `json:"floating,omitempty"`
+	Hugepages *string `json:"hugepages,omitempty"`
 }
 
 type ConfigureVirtualMachineCloudInitOptions struct {
-	User *ConfigureVirtualMachineCloudInitUserOptions `json:"user,omitempty"`
-	Ip   []ConfigureVirtualMachineCloudInitIpOptions  `json:"ip"`
-	Dns  *ConfigureVirtualMachineCloudInitDnsOptions  `json:"dns,omitempty"`
+	User   *ConfigureVirtualMachineCloudInitUserOptions `json:"user,omitempty"`
+	Ip     []ConfigureVirtualMachineCloudInitIpOptions  `json:"ip"`
+	Dns    *ConfigureVirtualMachineCloudInitDnsOptions  `json:"dns,omitempty"`
+	Custom *vm.VirtualMachineCloudInitCustom            `json:"custom,omitempty"`
 }
 
 type ConfigureVirtualMachineCloudInitUserOptions struct {
@@ -172,6 +250,21 @@ func FormNewDiskString(opts ConfigureVirtualMachineDiskOptions) *string {
 	return &diskstr
 }
 
+// the size of the volume is determined by proxmox from the efi type
+func FormEfiDiskString(opts ConfigureVirtualMachineEfiDiskOptions) *string {
+	diskstr := opts.Storage + ":1,efitype=" + opts.EfiType
+	if opts.PreEnrolledKeys {
+		diskstr = diskstr + ",pre-enrolled-keys=1"
+	}
+
+	return &diskstr
+}
+
+func FormTpmStateString(opts ConfigureVirtualMachineTpmStateOptions) *string {
+	diskstr := opts.Storage + ":1,version=" + opts.Version
+	return &diskstr
+}
+
 func FormDiskString(opts ConfigureVirtualMachineDiskOptions) *string {
 	diskstr := opts.Storage + ":" + *opts.Name
 	if opts.Discard {
@@ -263,8 +356,25 @@ func FormIpConfigString(v4 *ConfigureVirtualMachineCloudInitIpConfigOptions, v6
 	return &ipStr
 }
 
-func FormPCIDeviceString() *string {
-	return nil
+func FormPCIDeviceString(opts ConfigureVirtualPciDeviceOptions) *string {
+	pciStr := "host=" + *opts.DeviceId
+	if opts.PCIe {
+		pciStr = pciStr + ",pcie=1"
+	}
+	if opts.PrimaryGPU {
+		pciStr = pciStr + ",x-vga=1"
+	}
+	if !opts.ROMBAR {
+		pciStr = pciStr + ",rombar=0"
+	}
+	if opts.ROMFile != nil {
+		pciStr = pciStr + ",romfile=" + *opts.ROMFile
+	}
+	if opts.Mdev != nil {
+		pciStr = pciStr + ",mdev=" + *opts.Mdev
+	}
+
+	return &pciStr
 }
 
 func (c *Proxmox) ConfigureVirtualMachine(ctx context.Context, input *ConfigureVirtualMachineInput) error {
@@ -290,13 +400,32 @@ func (c *Proxmox) ConfigureVirtualMachine(ctx context.Context, input *ConfigureV
 		}
 		content.Cores = PtrIntToPtrFloat(input.CPU.Cores)
 		content.Sockets = PtrIntToPtrFloat(input.CPU.Sockets)
-		content.Cpu = input.CPU.EmulatedType
+		content.Cpu = vm.FormCpuString(input.CPU.EmulatedType, input.CPU.Flags, input.CPU.Hidden, input.CPU.HvVendorId)
 		content.Cpuunits = PtrInt64ToPtrFloat(input.CPU.CpuUnits)
+		if input.CPU.Limit != nil {
+			limit := float32(*input.CPU.Limit)
+			content.Cpulimit = &limit
+		}
+		content.Vcpus = PtrIntToPtrFloat(input.CPU.VCpus)
+		content.Affinity = input.CPU.Affinity
+		numa := float32(BoolToInt(input.CPU.Numa))
+		content.Numa = &numa
+		for _, n := range input.CPU.NumaNodes {
+			config := vm.FormNumaNodeString(n.Cpus, n.Memory, n.HostNodes, n.Policy)
+			err := vm.AllocateNumaNodeConfig(n.Position, config, &content)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	if input.Memory != nil {
 		content.Memory = PtrInt64ToPtrFloat(input.Memory.Dedicated)
 		content.Ballon = PtrInt64ToPtrFloat(input.Memory.Floating)
 		content.Shares = PtrInt64ToPtrFloat(input.Memory.Shared)
+		if input.Memory.Hugepages != nil {
+			hugepages := proxmox.VirtualMachineHugePages(*input.Memory.Hugepages)
+			content.Hugepages = &hugepages
+		}
 	}
 	if input.CloudInit != nil {
 		if input.CloudInit.User != nil {
@@ -308,6 +437,9 @@ func (c *Proxmox) ConfigureVirtualMachine(ctx context.Context, input *ConfigureV
 			content.Searchdomain = input.CloudInit.Dns.Domain
 			content.Nameserver = input.CloudInit.Dns.Nameserver
 		}
+		if input.CloudInit.Custom != nil {
+			content.Cicustom = vm.FormCloudInitCustomString(input.CloudInit.Custom)
+		}
 		for _, n := range input.CloudInit.Ip {
 			config := FormIpConfigString(n.V4, n.V6)
 			fmt.Println("config str: ", *config)
@@ -317,6 +449,12 @@ func (c *Proxmox) ConfigureVirtualMachine(ctx context.Context, input *ConfigureV
 			}
 		}
 	}
+	if input.Hotplug != nil {
+		content.Hotplug = vm.FormHotplugString(input.Hotplug)
+	}
+	if len(input.BootOrder) > 0 {
+		content.Boot = vm.FormBootOrderString(input.BootOrder)
+	}
 	if input.StartOnBoot {
 		onboot := float32(1)
 		content.Onboot = &onboot
@@ -342,8 +480,64 @@ func (c *Proxmox) ConfigureVirtualMachine(ctx context.Context, input *ConfigureV
 		}
 	}
 
+	if input.EfiDisk != nil {
+		err := vm.AllocateDiskConfig(vm.DiskInterfaceEfi, 0, FormEfiDiskString(*input.EfiDisk), &content)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.TpmState != nil {
+		err := vm.AllocateDiskConfig(vm.DiskInterfaceTpm, 0, FormTpmStateString(*input.TpmState), &content)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range input.SerialDevices {
+		config := s.Type
+		err := vm.AllocateSerialDeviceConfig(s.Position, &config, &content)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, u := range input.USBDevices {
+		config := vm.FormUSBDeviceString(u.Host, u.Mapping, u.USB3)
+		err := vm.AllocateUSBDeviceConfig(u.Position, config, &content)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.Display != nil {
+		content.Vga = vm.FormDisplayString(input.Display.Type, input.Display.Memory)
+		if input.Display.SpiceFolderSharing || input.Display.SpiceVideoStreaming != nil {
+			content.SpiceEnhancements = vm.FormSpiceEnhancementsString(input.Display.SpiceFolderSharing, input.Display.SpiceVideoStreaming)
+		}
+	}
+
+	if input.Rng != nil {
+		content.Rng0 = vm.FormRngString(input.Rng.Source, input.Rng.MaxBytes, input.Rng.Period)
+	}
+
+	if input.Watchdog != nil {
+		content.Watchdog = vm.FormWatchdogString(input.Watchdog.Model, input.Watchdog.Action)
+	}
+
+	if input.Audio != nil {
+		content.Audio0 = vm.FormAudioString(input.Audio.Device, input.Audio.Driver)
+	}
+
 	for _, p := range input.PCIDevices {
-		fmt.Println(p)
+		if p.DeviceId == nil {
+			return fmt.Errorf("pci device %v has no device id", p.Position)
+		}
+		config := FormPCIDeviceString(p)
+		err := vm.AllocatePCIDeviceConfig(p.Position, config, &content)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, n := range input.NetworkInterfaces {
@@ -1,9 +1,11 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 type ProxmoxError struct {
@@ -41,3 +43,23 @@ func ApiError(h *http.Response, e error) error {
 		Message:    msg,
 	}
 }
+
+// the messages proxmox fails creating a virtual machine with when its id is already used, either by a config
+// file on the node or by a virtual machine elsewhere in the cluster
+var vmAlreadyExistsMessages = []string{
+	"config file already exists",
+	"already exists on node",
+}
+
+func IsAlreadyExists(e error) bool {
+	var perr *ProxmoxError
+	if !errors.As(e, &perr) || perr.StatusCode != http.StatusInternalServerError {
+		return false
+	}
+	for _, m := range vmAlreadyExistsMessages {
+		if strings.Contains(perr.Err, m) || strings.Contains(perr.Message, m) {
+			return true
+		}
+	}
+	return false
+}
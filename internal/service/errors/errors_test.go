@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAlreadyExists(t *testing.T) {
+	assert.False(t, IsAlreadyExists(nil))
+	assert.False(t, IsAlreadyExists(fmt.Errorf("unable to create VM 100: config file already exists")))
+
+	assert.True(t, IsAlreadyExists(&ProxmoxError{
+		StatusCode: http.StatusInternalServerError,
+		Err:        "500 unable to create VM 100: config file already exists",
+		Message:    `{"data":null}`,
+	}))
+	assert.True(t, IsAlreadyExists(fmt.Errorf("clone failed: %w", &ProxmoxError{
+		StatusCode: http.StatusInternalServerError,
+		Err:        "500 unable to restore VM 100 - VM 100 already exists on node 'pve2'",
+		Message:    `{"data":null}`,
+	})))
+
+	assert.False(t, IsAlreadyExists(&ProxmoxError{
+		StatusCode: http.StatusBadRequest,
+		Err:        "400 Parameter verification failed.",
+		Message:    `{"errors":{"name":"already exists"},"data":null}`,
+	}))
+	assert.False(t, IsAlreadyExists(&ProxmoxError{
+		StatusCode: http.StatusInternalServerError,
+		Err:        "500 storage 'local' already exists",
+		Message:    `{"data":null}`,
+	}))
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const ClusterFirewallPath = "/cluster/firewall"
+
+// firewall configuration is identical for the cluster, nodes and virtual machines, only the path of
+// the api differs
+func VirtualMachineFirewallPath(node string, vmid int) string {
+	return fmt.Sprintf("/nodes/%s/qemu/%d/firewall", node, vmid)
+}
+
+func NodeFirewallPath(node string) string {
+	return fmt.Sprintf("/nodes/%s/firewall", node)
+}
+
+// rules are listed under the rules path of a firewall, or directly under a security group
+func FirewallRulesPath(firewall string) string {
+	return firewall + "/rules"
+}
+
+func SecurityGroupRulesPath(group string) string {
+	return ClusterFirewallPath + "/groups/" + url.PathEscape(group)
+}
+
+type FirewallRule struct {
+	Pos     int     `json:"pos"`
+	Type    string  `json:"type"`
+	Action  string  `json:"action"`
+	Enable  *int    `json:"enable,omitempty"`
+	Proto   *string `json:"proto,omitempty"`
+	Dport   *string `json:"dport,omitempty"`
+	Sport   *string `json:"sport,omitempty"`
+	Source  *string `json:"source,omitempty"`
+	Dest    *string `json:"dest,omitempty"`
+	Macro   *string `json:"macro,omitempty"`
+	Iface   *string `json:"iface,omitempty"`
+	Log     *string `json:"log,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+func (r *FirewallRule) optionalFields() map[string]*string {
+	return map[string]*string{
+		"proto":   r.Proto,
+		"dport":   r.Dport,
+		"sport":   r.Sport,
+		"source":  r.Source,
+		"dest":    r.Dest,
+		"macro":   r.Macro,
+		"iface":   r.Iface,
+		"log":     r.Log,
+		"comment": r.Comment,
+	}
+}
+
+func (r *FirewallRule) IsEnabled() bool {
+	return r.Enable != nil && IntToBool(*r.Enable)
+}
+
+// compares the rules ignoring their position
+func (r *FirewallRule) Equal(o *FirewallRule) bool {
+	if r.Type != o.Type || r.Action != o.Action || r.IsEnabled() != o.IsEnabled() {
+		return false
+	}
+	other := o.optionalFields()
+	for k, v := range r.optionalFields() {
+		if PtrStringToString(v) != PtrStringToString(other[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// forms the request body of the rule, unset fields are deleted when updating an existing rule
+func (r *FirewallRule) body(update bool) map[string]interface{} {
+	body := map[string]interface{}{
+		"type":   r.Type,
+		"action": r.Action,
+		"enable": BoolToInt(r.IsEnabled()),
+	}
+	deletes := []string{}
+	for k, v := range r.optionalFields() {
+		if v != nil && *v != "" {
+			body[k] = *v
+		} else if update {
+			deletes = append(deletes, k)
+		}
+	}
+	if len(deletes) > 0 {
+		body["delete"] = strings.Join(deletes, ",")
+	}
+	return body
+}
+
+func (c *Proxmox) ListFirewallRules(ctx context.Context, rules string) ([]FirewallRule, error) {
+	var result []FirewallRule
+	err := c.request(ctx, http.MethodGet, rules, nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// inserts the rule at its position, rules at or after the position are moved down
+func (c *Proxmox) CreateFirewallRule(ctx context.Context, rules string, rule *FirewallRule) error {
+	body := rule.body(false)
+	body["pos"] = rule.Pos
+	return c.request(ctx, http.MethodPost, rules, nil, body, nil)
+}
+
+func (c *Proxmox) UpdateFirewallRule(ctx context.Context, rules string, rule *FirewallRule) error {
+	return c.request(ctx, http.MethodPut, fmt.Sprintf("%s/%d", rules, rule.Pos), nil, rule.body(true), nil)
+}
+
+func (c *Proxmox) DeleteFirewallRule(ctx context.Context, rules string, pos int) error {
+	return c.request(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", rules, pos), nil, nil, nil)
+}
+
+type FirewallIPSet struct {
+	Name    string  `json:"name"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+type FirewallIPSetEntry struct {
+	CIDR    string  `json:"cidr"`
+	NoMatch *int    `json:"nomatch,omitempty"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+func ipSetPath(firewall string, name string) string {
+	return firewall + "/ipset/" + url.PathEscape(name)
+}
+
+func ipSetEntryPath(firewall string, name string, cidr string) string {
+	return ipSetPath(firewall, name) + "/" + url.PathEscape(cidr)
+}
+
+func (c *Proxmox) ListFirewallIPSets(ctx context.Context, firewall string) ([]FirewallIPSet, error) {
+	var result []FirewallIPSet
+	err := c.request(ctx, http.MethodGet, firewall+"/ipset", nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// returns nil when the ip set does not exist
+func (c *Proxmox) DescribeFirewallIPSet(ctx context.Context, firewall string, name string) (*FirewallIPSet, error) {
+	ipSets, err := c.ListFirewallIPSets(ctx, firewall)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range ipSets {
+		if strings.EqualFold(s.Name, name) {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Proxmox) CreateFirewallIPSet(ctx context.Context, firewall string, name string, comment *string) error {
+	body := map[string]interface{}{
+		"name": name,
+	}
+	if comment != nil {
+		body["comment"] = *comment
+	}
+	return c.request(ctx, http.MethodPost, firewall+"/ipset", nil, body, nil)
+}
+
+// proxmox updates the comment of an ip set by renaming it to itself
+func (c *Proxmox) UpdateFirewallIPSetComment(ctx context.Context, firewall string, name string, comment *string) error {
+	body := map[string]interface{}{
+		"name":    name,
+		"rename":  name,
+		"comment": PtrStringToString(comment),
+	}
+	return c.request(ctx, http.MethodPost, firewall+"/ipset", nil, body, nil)
+}
+
+// the ip set must be empty to be deleted
+func (c *Proxmox) DeleteFirewallIPSet(ctx context.Context, firewall string, name string) error {
+	return c.request(ctx, http.MethodDelete, ipSetPath(firewall, name), nil, nil, nil)
+}
+
+func (c *Proxmox) ListFirewallIPSetEntries(ctx context.Context, firewall string, name string) ([]FirewallIPSetEntry, error) {
+	var result []FirewallIPSetEntry
+	err := c.request(ctx, http.MethodGet, ipSetPath(firewall, name), nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (e *FirewallIPSetEntry) body() map[string]interface{} {
+	return map[string]interface{}{
+		"nomatch": BoolToInt(e.NoMatch != nil && IntToBool(*e.NoMatch)),
+		"comment": PtrStringToString(e.Comment),
+	}
+}
+
+func (c *Proxmox) CreateFirewallIPSetEntry(ctx context.Context, firewall string, name string, entry *FirewallIPSetEntry) error {
+	body := entry.body()
+	body["cidr"] = entry.CIDR
+	return c.request(ctx, http.MethodPost, ipSetPath(firewall, name), nil, body, nil)
+}
+
+func (c *Proxmox) UpdateFirewallIPSetEntry(ctx context.Context, firewall string, name string, entry *FirewallIPSetEntry) error {
+	return c.request(ctx, http.MethodPut, ipSetEntryPath(firewall, name, entry.CIDR), nil, entry.body(), nil)
+}
+
+func (c *Proxmox) DeleteFirewallIPSetEntry(ctx context.Context, firewall string, name string, cidr string) error {
+	return c.request(ctx, http.MethodDelete, ipSetEntryPath(firewall, name, cidr), nil, nil, nil)
+}
+
+type FirewallAlias struct {
+	Name    string  `json:"name"`
+	CIDR    string  `json:"cidr"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+func aliasPath(firewall string, name string) string {
+	return firewall + "/aliases/" + url.PathEscape(name)
+}
+
+// returns nil when the alias does not exist
+func (c *Proxmox) DescribeFirewallAlias(ctx context.Context, firewall string, name string) (*FirewallAlias, error) {
+	var aliases []FirewallAlias
+	err := c.request(ctx, http.MethodGet, firewall+"/aliases", nil, nil, &aliases)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases {
+		if strings.EqualFold(a.Name, name) {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Proxmox) CreateFirewallAlias(ctx context.Context, firewall string, alias *FirewallAlias) error {
+	body := map[string]interface{}{
+		"name": alias.Name,
+		"cidr": alias.CIDR,
+	}
+	if alias.Comment != nil {
+		body["comment"] = *alias.Comment
+	}
+	return c.request(ctx, http.MethodPost, firewall+"/aliases", nil, body, nil)
+}
+
+func (c *Proxmox) UpdateFirewallAlias(ctx context.Context, firewall string, alias *FirewallAlias) error {
+	body := map[string]interface{}{
+		"cidr":    alias.CIDR,
+		"comment": PtrStringToString(alias.Comment),
+	}
+	return c.request(ctx, http.MethodPut, aliasPath(firewall, alias.Name), nil, body, nil)
+}
+
+func (c *Proxmox) DeleteFirewallAlias(ctx context.Context, firewall string, name string) error {
+	return c.request(ctx, http.MethodDelete, aliasPath(firewall, name), nil, nil, nil)
+}
+
+type VirtualMachineFirewallOptions struct {
+	Enable      *int    `json:"enable,omitempty"`
+	Dhcp        *int    `json:"dhcp,omitempty"`
+	Ndp         *int    `json:"ndp,omitempty"`
+	Radv        *int    `json:"radv,omitempty"`
+	IpFilter    *int    `json:"ipfilter,omitempty"`
+	MacFilter   *int    `json:"macfilter,omitempty"`
+	PolicyIn    *string `json:"policy_in,omitempty"`
+	PolicyOut   *string `json:"policy_out,omitempty"`
+	LogLevelIn  *string `json:"log_level_in,omitempty"`
+	LogLevelOut *string `json:"log_level_out,omitempty"`
+}
+
+func (c *Proxmox) GetVirtualMachineFirewallOptions(ctx context.Context, node string, vmid int) (*VirtualMachineFirewallOptions, error) {
+	var options VirtualMachineFirewallOptions
+	err := c.request(ctx, http.MethodGet, VirtualMachineFirewallPath(node, vmid)+"/options", nil, nil, &options)
+	if err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+// options that are not set are left unchanged, options in delete are reset to their defaults
+func (c *Proxmox) UpdateFirewallOptions(ctx context.Context, firewall string, options map[string]interface{}, delete []string) error {
+	body := map[string]interface{}{}
+	for k, v := range options {
+		body[k] = v
+	}
+	if len(delete) > 0 {
+		body["delete"] = strings.Join(delete, ",")
+	}
+	return c.request(ctx, http.MethodPut, firewall+"/options", nil, body, nil)
+}
+
+type FirewallSecurityGroup struct {
+	Group   string  `json:"group"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// returns nil when the security group does not exist
+func (c *Proxmox) DescribeFirewallSecurityGroup(ctx context.Context, group string) (*FirewallSecurityGroup, error) {
+	var groups []FirewallSecurityGroup
+	err := c.request(ctx, http.MethodGet, ClusterFirewallPath+"/groups", nil, nil, &groups)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if strings.EqualFold(g.Group, group) {
+			return &g, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Proxmox) CreateFirewallSecurityGroup(ctx context.Context, group string, comment *string) error {
+	body := map[string]interface{}{
+		"group": group,
+	}
+	if comment != nil {
+		body["comment"] = *comment
+	}
+	return c.request(ctx, http.MethodPost, ClusterFirewallPath+"/groups", nil, body, nil)
+}
+
+// like ip sets, the comment of a security group is updated by renaming it to itself
+func (c *Proxmox) UpdateFirewallSecurityGroupComment(ctx context.Context, group string, comment *string) error {
+	body := map[string]interface{}{
+		"group":   group,
+		"rename":  group,
+		"comment": PtrStringToString(comment),
+	}
+	return c.request(ctx, http.MethodPost, ClusterFirewallPath+"/groups", nil, body, nil)
+}
+
+// the security group must not contain rules to be deleted
+func (c *Proxmox) DeleteFirewallSecurityGroup(ctx context.Context, group string) error {
+	return c.request(ctx, http.MethodDelete, SecurityGroupRulesPath(group), nil, nil, nil)
+}
+
+type FirewallLogRateLimit struct {
+	Enabled bool
+	Burst   int
+	Rate    string
+}
+
+// the rate limit is formatted as `enable=1,burst=5,rate=1/second`
+func DetermineFirewallLogRateLimit(s *string) *FirewallLogRateLimit {
+	limit := FirewallLogRateLimit{
+		Enabled: true,
+		Burst:   5,
+		Rate:    "1/second",
+	}
+	if s == nil {
+		return &limit
+	}
+	for _, p := range strings.Split(*s, ",") {
+		k, v, found := strings.Cut(p, "=")
+		if !found {
+			continue
+		}
+		switch k {
+		case "enable":
+			limit.Enabled = v == "1"
+		case "burst":
+			if b, err := strconv.Atoi(v); err == nil {
+				limit.Burst = b
+			}
+		case "rate":
+			limit.Rate = v
+		}
+	}
+	return &limit
+}
+
+func FormFirewallLogRateLimit(l *FirewallLogRateLimit) string {
+	return fmt.Sprintf("enable=%d,burst=%d,rate=%s", BoolToInt(l.Enabled), l.Burst, l.Rate)
+}
+
+type ClusterFirewallOptions struct {
+	Enable       *int    `json:"enable,omitempty"`
+	Ebtables     *int    `json:"ebtables,omitempty"`
+	PolicyIn     *string `json:"policy_in,omitempty"`
+	PolicyOut    *string `json:"policy_out,omitempty"`
+	LogRateLimit *string `json:"log_ratelimit,omitempty"`
+}
+
+func (c *Proxmox) GetClusterFirewallOptions(ctx context.Context) (*ClusterFirewallOptions, error) {
+	var options ClusterFirewallOptions
+	err := c.request(ctx, http.MethodGet, ClusterFirewallPath+"/options", nil, nil, &options)
+	if err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
+
+type NodeFirewallOptions struct {
+	Enable                           *int    `json:"enable,omitempty"`
+	Ndp                              *int    `json:"ndp,omitempty"`
+	LogLevelIn                       *string `json:"log_level_in,omitempty"`
+	LogLevelOut                      *string `json:"log_level_out,omitempty"`
+	LogNfConntrack                   *int    `json:"log_nf_conntrack,omitempty"`
+	NfConntrackAllowInvalid          *int    `json:"nf_conntrack_allow_invalid,omitempty"`
+	NfConntrackHelpers               *string `json:"nf_conntrack_helpers,omitempty"`
+	NfConntrackMax                   *int    `json:"nf_conntrack_max,omitempty"`
+	NfConntrackTcpTimeoutEstablished *int    `json:"nf_conntrack_tcp_timeout_established,omitempty"`
+	NfConntrackTcpTimeoutSynRecv     *int    `json:"nf_conntrack_tcp_timeout_syn_recv,omitempty"`
+	NoSmurfs                         *int    `json:"nosmurfs,omitempty"`
+	SmurfLogLevel                    *string `json:"smurf_log_level,omitempty"`
+	TcpFlags                         *int    `json:"tcpflags,omitempty"`
+	TcpFlagsLogLevel                 *string `json:"tcp_flags_log_level,omitempty"`
+}
+
+func (c *Proxmox) GetNodeFirewallOptions(ctx context.Context, node string) (*NodeFirewallOptions, error) {
+	var options NodeFirewallOptions
+	err := c.request(ctx, http.MethodGet, NodeFirewallPath(node)+"/options", nil, nil, &options)
+	if err != nil {
+		return nil, err
+	}
+	return &options, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type agentResult[T any] struct {
+	Result T `json:"result"`
+}
+
+func (c *Proxmox) agentRequest(ctx context.Context, node string, vmid int, command string, out interface{}) error {
+	path := fmt.Sprintf("/nodes/%s/qemu/%v/agent/%s", node, vmid, command)
+	return c.request(ctx, http.MethodGet, path, nil, nil, out)
+}
+
+// reads the network interfaces, hostname and os of the guest, only the network interfaces are
+// required as older agents do not support every command
+func (c *Proxmox) DescribeVirtualMachineGuest(ctx context.Context, node string, vmid int) (*vm.VirtualMachineGuest, error) {
+	var ifaces agentResult[[]vm.GuestAgentNetworkInterface]
+	err := c.agentRequest(ctx, node, vmid, "network-get-interfaces", &ifaces)
+	if err != nil {
+		return nil, err
+	}
+
+	guest := vm.VirtualMachineGuest{
+		NetworkInterfaces: vm.DetermineGuestNetworkInterfaces(ifaces.Result),
+	}
+
+	var hostname agentResult[vm.GuestAgentHostname]
+	err = c.agentRequest(ctx, node, vmid, "get-host-name", &hostname)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("unable to read guest hostname: %v", err))
+	} else {
+		guest.Hostname = &hostname.Result.Hostname
+	}
+
+	var osInfo agentResult[vm.GuestAgentOsInfo]
+	err = c.agentRequest(ctx, node, vmid, "get-osinfo", &osInfo)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("unable to read guest os info: %v", err))
+	} else {
+		guest.OsInfo = &osInfo.Result
+	}
+
+	return &guest, nil
+}
+
+// the guest agent can only answer while the virtual machine is running with the agent enabled
+func (c *Proxmox) determineGuest(ctx context.Context, node string, vmid int, agent *vm.VirtualMachineAgent) *vm.VirtualMachineGuest {
+	if agent == nil || !agent.Enabled {
+		return nil
+	}
+
+	status, err := c.GetVirtualMachineStatus(ctx, node, vmid)
+	if err != nil || status.Status != proxmox.VIRTUALMACHINESTATUS_RUNNING {
+		return nil
+	}
+
+	guest, err := c.DescribeVirtualMachineGuest(ctx, node, vmid)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("guest agent of virtual machine %v is not responding: %v", vmid, err))
+		return nil
+	}
+	return guest
+}
+
+// the agent reports booleans as json booleans while proxmox uses integers
+type agentBool bool
+
+func (b *agentBool) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	*b = agentBool(s == "true" || s == "1")
+	return nil
+}
+
+type GuestExecStatus struct {
+	Exited   agentBool `json:"exited"`
+	ExitCode *int      `json:"exitcode,omitempty"`
+	Signal   *int      `json:"signal,omitempty"`
+	OutData  *string   `json:"out-data,omitempty"`
+	ErrData  *string   `json:"err-data,omitempty"`
+}
+
+// starts the command in the guest and returns its pid, the command is not run through a shell
+func (c *Proxmox) ExecVirtualMachineGuestCommand(ctx context.Context, node string, vmid int, command []string, input *string) (int, error) {
+	body := map[string]interface{}{
+		"command": command,
+	}
+	if input != nil {
+		body["input-data"] = *input
+	}
+
+	var out struct {
+		Pid int `json:"pid"`
+	}
+	path := fmt.Sprintf("/nodes/%s/qemu/%v/agent/exec", node, vmid)
+	err := c.request(ctx, http.MethodPost, path, nil, body, &out)
+	if err != nil {
+		return 0, err
+	}
+	return out.Pid, nil
+}
+
+func (c *Proxmox) GetVirtualMachineGuestExecStatus(ctx context.Context, node string, vmid int, pid int) (*GuestExecStatus, error) {
+	query := url.Values{}
+	query.Set("pid", strconv.Itoa(pid))
+
+	var status GuestExecStatus
+	path := fmt.Sprintf("/nodes/%s/qemu/%v/agent/exec-status", node, vmid)
+	err := c.request(ctx, http.MethodGet, path, query, nil, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// the agent limits writes to 60KiB, proxmox base64 encodes the content for it
+func (c *Proxmox) WriteVirtualMachineGuestFile(ctx context.Context, node string, vmid int, file string, content string) error {
+	body := map[string]interface{}{
+		"file":    file,
+		"content": content,
+	}
+	path := fmt.Sprintf("/nodes/%s/qemu/%v/agent/file-write", node, vmid)
+	return c.request(ctx, http.MethodPost, path, nil, body, nil)
+}
+
+// returns the content of the file, reads are limited to 16MiB by the agent
+func (c *Proxmox) ReadVirtualMachineGuestFile(ctx context.Context, node string, vmid int, file string) (string, error) {
+	query := url.Values{}
+	query.Set("file", file)
+
+	var out struct {
+		Content string `json:"content"`
+	}
+	path := fmt.Sprintf("/nodes/%s/qemu/%v/agent/file-read", node, vmid)
+	err := c.request(ctx, http.MethodGet, path, query, nil, &out)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
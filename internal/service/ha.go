@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type HAGroupNode struct {
+	Node     string
+	Priority *int
+}
+
+type HAGroup struct {
+	Group      string
+	Nodes      []HAGroupNode
+	Restricted bool
+	NoFailback bool
+	Comment    *string
+}
+
+type haGroupSummary struct {
+	Group      string  `json:"group"`
+	Nodes      string  `json:"nodes"`
+	Restricted *int    `json:"restricted,omitempty"`
+	NoFailback *int    `json:"nofailback,omitempty"`
+	Comment    *string `json:"comment,omitempty"`
+}
+
+type HAResourceState string
+
+const (
+	HAResourceStateStarted  HAResourceState = "started"
+	HAResourceStateStopped  HAResourceState = "stopped"
+	HAResourceStateDisabled HAResourceState = "disabled"
+	HAResourceStateIgnored  HAResourceState = "ignored"
+)
+
+type HAResource struct {
+	Sid         string          `json:"sid"`
+	State       HAResourceState `json:"state"`
+	Group       *string         `json:"group,omitempty"`
+	MaxRestart  *int            `json:"max_restart,omitempty"`
+	MaxRelocate *int            `json:"max_relocate,omitempty"`
+	Comment     *string         `json:"comment,omitempty"`
+}
+
+// proxmox only enforces the state of resources that are started or stopped
+func (r *HAResource) IsManaged() bool {
+	return r.State == HAResourceStateStarted || r.State == HAResourceStateStopped
+}
+
+func FormVirtualMachineHASid(vmid int) string {
+	return fmt.Sprintf("vm:%v", vmid)
+}
+
+// nodes are formatted as `node1:2,node2`, where the optional number is the priority of the node
+func DetermineHAGroupNodes(s string) []HAGroupNode {
+	nodes := []HAGroupNode{}
+	for _, n := range strings.Split(s, ",") {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		node := HAGroupNode{Node: n}
+		if name, priority, found := strings.Cut(n, ":"); found {
+			node.Node = name
+			if p, err := strconv.Atoi(priority); err == nil {
+				node.Priority = &p
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func FormHAGroupNodes(nodes []HAGroupNode) string {
+	l := []string{}
+	for _, n := range nodes {
+		if n.Priority != nil {
+			l = append(l, fmt.Sprintf("%s:%v", n.Node, *n.Priority))
+		} else {
+			l = append(l, n.Node)
+		}
+	}
+	return strings.Join(l, ",")
+}
+
+func haGroupPath(group string) string {
+	return "/cluster/ha/groups/" + group
+}
+
+// returns nil when the group does not exist
+func (c *Proxmox) DescribeHAGroup(ctx context.Context, group string) (*HAGroup, error) {
+	var summaries []haGroupSummary
+	err := c.request(ctx, http.MethodGet, "/cluster/ha/groups", nil, nil, &summaries)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summary := range summaries {
+		if summary.Group != group {
+			continue
+		}
+		return &HAGroup{
+			Group:      group,
+			Nodes:      DetermineHAGroupNodes(summary.Nodes),
+			Restricted: summary.Restricted != nil && IntToBool(*summary.Restricted),
+			NoFailback: summary.NoFailback != nil && IntToBool(*summary.NoFailback),
+			Comment:    summary.Comment,
+		}, nil
+	}
+	return nil, nil
+}
+
+type CreateHAGroupInput struct {
+	Group      string
+	Nodes      []HAGroupNode
+	Restricted bool
+	NoFailback bool
+	Comment    *string
+}
+
+func (c *Proxmox) CreateHAGroup(ctx context.Context, input *CreateHAGroupInput) error {
+	body := map[string]interface{}{
+		"group":      input.Group,
+		"type":       "group",
+		"nodes":      FormHAGroupNodes(input.Nodes),
+		"restricted": BoolToInt(input.Restricted),
+		"nofailback": BoolToInt(input.NoFailback),
+	}
+	if input.Comment != nil {
+		body["comment"] = *input.Comment
+	}
+
+	return c.request(ctx, http.MethodPost, "/cluster/ha/groups", nil, body, nil)
+}
+
+type UpdateHAGroupInput struct {
+	Group      string
+	Nodes      []HAGroupNode
+	Restricted bool
+	NoFailback bool
+	Comment    *string
+}
+
+func (c *Proxmox) UpdateHAGroup(ctx context.Context, input *UpdateHAGroupInput) error {
+	body := map[string]interface{}{
+		"nodes":      FormHAGroupNodes(input.Nodes),
+		"restricted": BoolToInt(input.Restricted),
+		"nofailback": BoolToInt(input.NoFailback),
+	}
+	if input.Comment != nil {
+		body["comment"] = *input.Comment
+	} else {
+		body["delete"] = "comment"
+	}
+
+	return c.request(ctx, http.MethodPut, haGroupPath(input.Group), nil, body, nil)
+}
+
+func (c *Proxmox) DeleteHAGroup(ctx context.Context, group string) error {
+	return c.request(ctx, http.MethodDelete, haGroupPath(group), nil, nil, nil)
+}
+
+func haResourcePath(sid string) string {
+	return "/cluster/ha/resources/" + sid
+}
+
+func (c *Proxmox) ListHAResources(ctx context.Context) ([]HAResource, error) {
+	var resources []HAResource
+	err := c.request(ctx, http.MethodGet, "/cluster/ha/resources", nil, nil, &resources)
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// returns nil when the resource is not part of the ha configuration
+func (c *Proxmox) DescribeHAResource(ctx context.Context, sid string) (*HAResource, error) {
+	resources, err := c.ListHAResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range resources {
+		if r.Sid != sid {
+			continue
+		}
+		if r.State == "" {
+			r.State = HAResourceStateStarted
+		}
+		return &r, nil
+	}
+	return nil, nil
+}
+
+func (c *Proxmox) DescribeVirtualMachineHAResource(ctx context.Context, vmid int) (*HAResource, error) {
+	return c.DescribeHAResource(ctx, FormVirtualMachineHASid(vmid))
+}
+
+type HAResourceInput struct {
+	Sid         string
+	State       HAResourceState
+	Group       *string
+	MaxRestart  int
+	MaxRelocate int
+	Comment     *string
+}
+
+func (c *Proxmox) CreateHAResource(ctx context.Context, input *HAResourceInput) error {
+	body := map[string]interface{}{
+		"sid":          input.Sid,
+		"state":        input.State,
+		"max_restart":  input.MaxRestart,
+		"max_relocate": input.MaxRelocate,
+	}
+	if input.Group != nil {
+		body["group"] = *input.Group
+	}
+	if input.Comment != nil {
+		body["comment"] = *input.Comment
+	}
+
+	return c.request(ctx, http.MethodPost, "/cluster/ha/resources", nil, body, nil)
+}
+
+func (c *Proxmox) UpdateHAResource(ctx context.Context, input *HAResourceInput) error {
+	body := map[string]interface{}{
+		"state":        input.State,
+		"max_restart":  input.MaxRestart,
+		"max_relocate": input.MaxRelocate,
+	}
+	deletes := []string{}
+	if input.Group != nil {
+		body["group"] = *input.Group
+	} else {
+		deletes = append(deletes, "group")
+	}
+	if input.Comment != nil {
+		body["comment"] = *input.Comment
+	} else {
+		deletes = append(deletes, "comment")
+	}
+	if len(deletes) > 0 {
+		body["delete"] = strings.Join(deletes, ",")
+	}
+
+	return c.request(ctx, http.MethodPut, haResourcePath(input.Sid), nil, body, nil)
+}
+
+// requests the ha manager to bring the resource into the state, the change itself happens asynchronously
+func (c *Proxmox) SetHAResourceState(ctx context.Context, sid string, state HAResourceState) error {
+	body := map[string]interface{}{
+		"state": state,
+	}
+	return c.request(ctx, http.MethodPut, haResourcePath(sid), nil, body, nil)
+}
+
+func (c *Proxmox) DeleteHAResource(ctx context.Context, sid string) error {
+	return c.request(ctx, http.MethodDelete, haResourcePath(sid), nil, nil, nil)
+}
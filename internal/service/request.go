@@ -0,0 +1,78 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+)
+
+type apiResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// the generated client does not cover every endpoint yet, this sends requests for the missing
+// ones using the same server, credentials and http client
+func (c *Proxmox) request(ctx context.Context, method string, path string, query url.Values, body map[string]interface{}, out interface{}) error {
+	endpoint := c.config.Servers[0].URL + path
+	if len(query) > 0 {
+		endpoint = endpoint + "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.config.DefaultHeader {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		return &errors.ProxmoxError{
+			StatusCode: resp.StatusCode,
+			Err:        resp.Status,
+			Message:    string(b),
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	var r apiResponse
+	err = json.Unmarshal(b, &r)
+	if err != nil {
+		return fmt.Errorf("unable to read response for %s: %w", path, err)
+	}
+
+	return json.Unmarshal(r.Data, out)
+}
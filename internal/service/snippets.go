@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type storageConfig struct {
+	Path    *string `json:"path,omitempty"`
+	Content string  `json:"content"`
+}
+
+func FormSnippetVolume(storage string, fileName string) string {
+	return fmt.Sprintf("%s:snippets/%s", storage, fileName)
+}
+
+// snippets live in the snippets directory of file based storages
+func (c *Proxmox) snippetPath(ctx context.Context, storage string, fileName string) (string, error) {
+	var cfg storageConfig
+	err := c.request(ctx, http.MethodGet, "/storage/"+url.PathEscape(storage), nil, nil, &cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.Path == nil {
+		return "", fmt.Errorf("storage %s has no path and cannot hold snippets", storage)
+	}
+	if !strings.Contains(cfg.Content, "snippets") {
+		return "", fmt.Errorf("storage %s does not allow snippets content", storage)
+	}
+
+	return fmt.Sprintf("%s/snippets/%s", strings.TrimSuffix(*cfg.Path, "/"), fileName), nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// the api does not accept snippets as upload content, so the file is written over ssh
+func (c *Proxmox) UploadSnippet(ctx context.Context, node string, storage string, fileName string, content string) error {
+	path, err := c.snippetPath(ctx, storage, fileName)
+	if err != nil {
+		return err
+	}
+
+	dir := path[:strings.LastIndex(path, "/")]
+	command := fmt.Sprintf("mkdir -p %s && cat > %s", shellQuote(dir), shellQuote(path))
+	_, err = c.runNodeCommand(ctx, node, command, strings.NewReader(content))
+	return err
+}
+
+// returns the sha256 of the snippet, or an empty string when it does not exist
+func (c *Proxmox) SnippetHash(ctx context.Context, node string, storage string, fileName string) (string, error) {
+	path, err := c.snippetPath(ctx, storage, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	command := fmt.Sprintf("if [ -f %[1]s ]; then sha256sum %[1]s; fi", shellQuote(path))
+	out, err := c.runNodeCommand(ctx, node, command, nil)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], nil
+}
+
+func (c *Proxmox) DeleteSnippet(ctx context.Context, node string, storage string, fileName string) error {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, url.PathEscape(FormSnippetVolume(storage, fileName)))
+	return c.request(ctx, http.MethodDelete, path, nil, nil, nil)
+}
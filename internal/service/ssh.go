@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// some operations, such as writing snippets, are not exposed by the api and are run on the node over ssh
+type SSHConfig struct {
+	Username       string
+	Password       string
+	PrivateKey     string
+	Port           int
+	KnownHostsFile string
+	Insecure       bool
+	NodeAddresses  map[string]string
+}
+
+type clusterNodeStatus struct {
+	Type string  `json:"type"`
+	Name string  `json:"name"`
+	IP   *string `json:"ip,omitempty"`
+}
+
+func (c *Proxmox) nodeAddress(ctx context.Context, node string) (string, error) {
+	if address, ok := c.ssh.NodeAddresses[node]; ok {
+		return address, nil
+	}
+
+	var status []clusterNodeStatus
+	err := c.request(ctx, http.MethodGet, "/cluster/status", nil, nil, &status)
+	if err != nil {
+		return "", err
+	}
+
+	for _, s := range status {
+		if s.Type == "node" && s.Name == node && s.IP != nil {
+			return *s.IP, nil
+		}
+	}
+
+	return node, nil
+}
+
+func (c *Proxmox) sshClientConfig() (*ssh.ClientConfig, error) {
+	auth := []ssh.AuthMethod{}
+	if c.ssh.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(c.ssh.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ssh private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if c.ssh.Password != "" {
+		auth = append(auth, ssh.Password(c.ssh.Password))
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if !c.ssh.Insecure {
+		if c.ssh.KnownHostsFile == "" {
+			return nil, fmt.Errorf("a known hosts file is required to verify node host keys")
+		}
+		callback, err := knownhosts.New(c.ssh.KnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read known hosts file: %w", err)
+		}
+		hostKeyCallback = callback
+	}
+
+	return &ssh.ClientConfig{
+		User:            c.ssh.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// runs the command on the node, piping stdin to it when set, and returns its output
+func (c *Proxmox) runNodeCommand(ctx context.Context, node string, command string, stdin io.Reader) (string, error) {
+	if c.ssh == nil {
+		return "", fmt.Errorf("ssh is not configured for the provider, it is required to run commands on node %s", node)
+	}
+
+	address, err := c.nodeAddress(ctx, node)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := c.sshClientConfig()
+	if err != nil {
+		return "", err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("running command on node %s over ssh", node))
+	client, err := ssh.Dial("tcp", net.JoinHostPort(address, strconv.Itoa(c.ssh.Port)), cfg)
+	if err != nil {
+		return "", fmt.Errorf("unable to connect to node %s over ssh: %w", node, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if stdin != nil {
+		session.Stdin = stdin
+	}
+
+	err = session.Run(command)
+	if err != nil {
+		return "", fmt.Errorf("command failed on node %s: %w: %s", node, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
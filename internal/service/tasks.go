@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const taskLogTailLength = 20
+
+type TaskStatus struct {
+	UPID       string `json:"upid"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	ExitStatus string `json:"exitstatus,omitempty"`
+}
+
+type taskLogLine struct {
+	N int    `json:"n"`
+	T string `json:"t"`
+}
+
+func (t *TaskStatus) IsRunning() bool {
+	return t.Status == "running"
+}
+
+func (t *TaskStatus) Succeeded() bool {
+	return t.Status == "stopped" && t.ExitStatus == "OK"
+}
+
+func (c *Proxmox) GetTaskStatus(ctx context.Context, node string, upid string) (*TaskStatus, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/status", node, url.PathEscape(upid))
+
+	var status TaskStatus
+	err := c.request(ctx, http.MethodGet, path, nil, nil, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (c *Proxmox) GetTaskLog(ctx context.Context, node string, upid string) ([]string, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/log", node, url.PathEscape(upid))
+	query := url.Values{}
+	query.Set("limit", "1000")
+
+	var lines []taskLogLine
+	err := c.request(ctx, http.MethodGet, path, query, nil, &lines)
+	if err != nil {
+		return nil, err
+	}
+
+	log := []string{}
+	for _, l := range lines {
+		log = append(log, l.T)
+	}
+
+	return log, nil
+}
+
+// polls the task until it stops, failed tasks return an error containing the end of the task log
+func (c *Proxmox) WaitForTask(ctx context.Context, node string, upid string, timeout int64) error {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		status, err := c.GetTaskStatus(ctx, node, upid)
+		if err != nil {
+			return err
+		}
+		if !status.IsRunning() {
+			if status.Succeeded() {
+				return nil
+			}
+			return c.taskError(ctx, node, upid, status.ExitStatus)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for task %s to finish", upid)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func (c *Proxmox) taskError(ctx context.Context, node string, upid string, exitStatus string) error {
+	log, err := c.GetTaskLog(ctx, node, upid)
+	if err != nil || len(log) == 0 {
+		return fmt.Errorf("task %s failed: %s", upid, exitStatus)
+	}
+
+	if len(log) > taskLogTailLength {
+		log = log[len(log)-taskLogTailLength:]
+	}
+
+	return fmt.Errorf("task %s failed: %s\n%s", upid, exitStatus, strings.Join(log, "\n"))
+}
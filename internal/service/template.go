@@ -1 +1,21 @@
-package service
\ No newline at end of file
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+)
+
+// converts the stopped virtual machine into a template and returns the task upid, a template
+// cannot be converted back
+func (c *Proxmox) ConvertVirtualMachineToTemplate(ctx context.Context, node string, vmid int) (string, error) {
+	vmId := strconv.Itoa(vmid)
+	request := c.client.CreateVirtualMachineTemplate(ctx, node, vmId)
+	resp, h, err := c.client.CreateVirtualMachineTemplateExecute(request)
+	if err != nil {
+		return "", errors.ApiError(h, err)
+	}
+
+	return PtrStringToString(resp.Data), nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/url"
 	"strings"
 )
@@ -9,6 +11,13 @@ func IntToBool(i int) bool {
 	return i != 0
 }
 
+func BoolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func BooleanIntegerConversion(i *float32) bool {
 	if i == nil {
 		return false
@@ -155,3 +164,9 @@ func PtrFloatToInt64(i *float32) int64 {
 	}
 	return int64(*i)
 }
+
+// matches the digest reported by sha256sum
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
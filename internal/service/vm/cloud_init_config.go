@@ -10,9 +10,18 @@ import (
 )
 
 type VirtualMachineCloudInit struct {
-	User *VirtualMachineCloudInitUser
-	Ip   []VirtualMachineCloudInitIp
-	Dns  *VirtualMachineCloudInitDns
+	User   *VirtualMachineCloudInitUser
+	Ip     []VirtualMachineCloudInitIp
+	Dns    *VirtualMachineCloudInitDns
+	Custom *VirtualMachineCloudInitCustom
+}
+
+// snippet volumes replacing the generated cloud-init files
+type VirtualMachineCloudInitCustom struct {
+	User    *string
+	Network *string
+	Vendor  *string
+	Meta    *string
 }
 
 type VirtualMachineCloudInitUser struct {
@@ -82,9 +91,58 @@ func DetermineCloudInitConfiguration(sum proxmox.VirtualMachineConfigurationSumm
 		ci.Dns = &ciDns
 	}
 
+	ci.Custom = DetermineCloudInitCustom(sum.Cicustom)
+
 	return &ci
 }
 
+func DetermineCloudInitCustom(c *string) *VirtualMachineCloudInitCustom {
+	if c == nil || *c == "" {
+		return nil
+	}
+
+	custom := VirtualMachineCloudInitCustom{}
+	for _, option := range strings.Split(*c, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "user":
+			custom.User = &value
+		case "network":
+			custom.Network = &value
+		case "vendor":
+			custom.Vendor = &value
+		case "meta":
+			custom.Meta = &value
+		}
+	}
+
+	return &custom
+}
+
+func FormCloudInitCustomString(custom *VirtualMachineCloudInitCustom) *string {
+	options := []string{}
+	for _, o := range []struct {
+		key    string
+		volume *string
+	}{
+		{"user", custom.User},
+		{"network", custom.Network},
+		{"vendor", custom.Vendor},
+		{"meta", custom.Meta},
+	} {
+		if o.volume != nil {
+			options = append(options, o.key+"="+*o.volume)
+		}
+	}
+
+	c := strings.Join(options, ",")
+	return &c
+}
+
 func setNetConfigs(cfg proxmox.VirtualMachineConfigurationSummary, config *VirtualMachineCloudInit) {
 	var cfgMap map[string]interface{}
 	inrec, _ := json.Marshal(cfg)
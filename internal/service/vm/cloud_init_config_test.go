@@ -0,0 +1,20 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineCloudInitCustom(t *testing.T) {
+	assert.Nil(t, DetermineCloudInitCustom(nil))
+
+	c := "user=local:snippets/user.yaml,network=local:snippets/network.yaml"
+	custom := DetermineCloudInitCustom(&c)
+	assert.Equal(t, "local:snippets/user.yaml", *custom.User)
+	assert.Equal(t, "local:snippets/network.yaml", *custom.Network)
+	assert.Nil(t, custom.Vendor)
+	assert.Nil(t, custom.Meta)
+
+	assert.Equal(t, c, *FormCloudInitCustomString(custom))
+}
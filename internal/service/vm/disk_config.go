@@ -142,6 +142,16 @@ func AllocateDiskConfig(t string, position int, config *string, input *proxmox.A
 		default:
 			return fmt.Errorf("invalid sata position")
 		}
+	case DiskInterfaceEfi:
+		if position != 0 {
+			return fmt.Errorf("invalid efidisk position")
+		}
+		input.Efidisk0 = config
+	case DiskInterfaceTpm:
+		if position != 0 {
+			return fmt.Errorf("invalid tpmstate position")
+		}
+		input.Tpmstate0 = config
 	default:
 		return fmt.Errorf("invalid disk type")
 	}
@@ -6,6 +6,7 @@ type VirtualMachineMemory struct {
 	Dedicated int64
 	Shared    *int64
 	Floating  *int64
+	Hugepages *string
 }
 
 func DetermineMemoryConfiguration(sum proxmox.VirtualMachineConfigurationSummary) VirtualMachineMemory {
@@ -25,5 +26,10 @@ func DetermineMemoryConfiguration(sum proxmox.VirtualMachineConfigurationSummary
 		mem.Floating = &floating
 	}
 
+	if sum.HasHugepages() {
+		hugepages := string(*sum.Hugepages)
+		mem.Hugepages = &hugepages
+	}
+
 	return mem
 }
@@ -0,0 +1,35 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+)
+
+func AllocatePCIDeviceConfig(position int, config *string, input *proxmox.ApplyVirtualMachineConfigurationSyncRequestContent) error {
+	switch position {
+	case 0:
+		input.Hostpci0 = config
+	case 1:
+		input.Hostpci1 = config
+	case 2:
+		input.Hostpci2 = config
+	case 3:
+		input.Hostpci3 = config
+	case 4:
+		input.Hostpci4 = config
+	case 5:
+		input.Hostpci5 = config
+	case 6:
+		input.Hostpci6 = config
+	case 7:
+		input.Hostpci7 = config
+	case 8:
+		input.Hostpci8 = config
+	case 9:
+		input.Hostpci9 = config
+	default:
+		return fmt.Errorf("invalid position %d", position)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package vm
+
+import "strings"
+
+func FormBootOrderString(devices []string) *string {
+	boot := "order=" + strings.Join(devices, ";")
+	return &boot
+}
+
+// the legacy boot format (e.g. "cdn") does not name devices, so it is treated as unset
+func DetermineBootOrder(b *string) []string {
+	if b == nil {
+		return []string{}
+	}
+
+	devices := []string{}
+	for _, option := range strings.Split(*b, ",") {
+		option = strings.TrimSpace(option)
+		if !strings.HasPrefix(option, "order=") {
+			continue
+		}
+		for _, d := range strings.Split(strings.TrimPrefix(option, "order="), ";") {
+			d = strings.TrimSpace(d)
+			if d != "" {
+				devices = append(devices, d)
+			}
+		}
+	}
+
+	return devices
+}
@@ -0,0 +1,21 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineBootOrder(t *testing.T) {
+	assert.Equal(t, []string{}, DetermineBootOrder(nil))
+
+	legacy := "cdn"
+	assert.Equal(t, []string{}, DetermineBootOrder(&legacy))
+
+	order := "order=scsi0;ide2;net0"
+	assert.Equal(t, []string{"scsi0", "ide2", "net0"}, DetermineBootOrder(&order))
+}
+
+func TestFormBootOrderString(t *testing.T) {
+	assert.Equal(t, "order=scsi0;net0", *FormBootOrderString([]string{"scsi0", "net0"}))
+}
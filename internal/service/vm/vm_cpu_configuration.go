@@ -1,6 +1,13 @@
 package vm
 
-import "github.com/awlsring/proxmox-go/proxmox"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	log "github.com/sirupsen/logrus"
+)
 
 type VirtualMachineCpu struct {
 	Architecture string
@@ -8,6 +15,22 @@ type VirtualMachineCpu struct {
 	Sockets      int
 	EmulatedType *string
 	CpuUnits     *int64
+	Limit        *float64
+	VCpus        *int
+	Affinity     *string
+	Flags        []string
+	Hidden       bool
+	HvVendorId   *string
+	Numa         bool
+	NumaNodes    []VirtualMachineNumaNode
+}
+
+type VirtualMachineNumaNode struct {
+	Position  int
+	Cpus      string
+	Memory    *int64
+	HostNodes *string
+	Policy    *string
 }
 
 func DetermineCPUConfiguration(sum proxmox.VirtualMachineConfigurationSummary) VirtualMachineCpu {
@@ -32,7 +55,7 @@ func DetermineCPUConfiguration(sum proxmox.VirtualMachineConfigurationSummary) V
 	}
 
 	if sum.HasCpu() {
-		cpu.EmulatedType = sum.Cpu
+		readCpuString(*sum.Cpu, &cpu)
 	}
 
 	if sum.HasCpuunits() {
@@ -40,5 +63,156 @@ func DetermineCPUConfiguration(sum proxmox.VirtualMachineConfigurationSummary) V
 		cpu.CpuUnits = &units
 	}
 
+	if sum.HasCpulimit() && *sum.Cpulimit != 0 {
+		// widen through the shortest decimal form so 0.3 doesn't read back as 0.30000001192
+		limit, _ := strconv.ParseFloat(strconv.FormatFloat(float64(*sum.Cpulimit), 'f', -1, 32), 64)
+		cpu.Limit = &limit
+	}
+
+	if sum.HasVcpus() {
+		vcpus := int(*sum.Vcpus)
+		cpu.VCpus = &vcpus
+	}
+
+	cpu.Affinity = sum.Affinity
+
+	if sum.HasNuma() {
+		cpu.Numa = *sum.Numa == 1
+	}
+
+	numa := []*string{sum.Numa0, sum.Numa1, sum.Numa2, sum.Numa3, sum.Numa4, sum.Numa5, sum.Numa6, sum.Numa7}
+	for i, n := range numa {
+		if n == nil {
+			continue
+		}
+		node, err := readNumaNodeString(*n)
+		if err != nil {
+			log.Warnf("skipping numa%v: %s", i, err.Error())
+			continue
+		}
+		node.Position = i
+		cpu.NumaNodes = append(cpu.NumaNodes, node)
+	}
+
 	return cpu
 }
+
+// the cputype key is optional when it is the first value, an example of the string is:
+// host,flags=+aes;-pcid,hidden=1,hv-vendor-id=proxmox
+func readCpuString(cpuString string, cpu *VirtualMachineCpu) {
+	for i, option := range strings.Split(cpuString, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			if i == 0 {
+				cpuType := option
+				cpu.EmulatedType = &cpuType
+			}
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "cputype":
+			cpu.EmulatedType = &value
+		case "flags":
+			cpu.Flags = strings.Split(value, ";")
+		case "hidden":
+			cpu.Hidden = value == "1"
+		case "hv-vendor-id":
+			cpu.HvVendorId = &value
+		default:
+			log.Warnf("unknown cpu option: %s", key)
+		}
+	}
+}
+
+// an example of the string is:
+// cpus=0-3;8,hostnodes=0,memory=2048,policy=bind
+func readNumaNodeString(numaString string) (VirtualMachineNumaNode, error) {
+	node := VirtualMachineNumaNode{}
+
+	for _, option := range strings.Split(numaString, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			return VirtualMachineNumaNode{}, fmt.Errorf("invalid numa node string: %s", numaString)
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "cpus":
+			node.Cpus = value
+		case "memory":
+			m, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return VirtualMachineNumaNode{}, fmt.Errorf("invalid numa node memory: %s", value)
+			}
+			node.Memory = &m
+		case "hostnodes":
+			node.HostNodes = &value
+		case "policy":
+			node.Policy = &value
+		default:
+			log.Warnf("unknown numa node option: %s", key)
+		}
+	}
+
+	return node, nil
+}
+
+func FormCpuString(emulatedType *string, flags []string, hidden bool, hvVendorId *string) *string {
+	options := []string{}
+	if emulatedType != nil {
+		options = append(options, "cputype="+*emulatedType)
+	}
+	if len(flags) > 0 {
+		options = append(options, "flags="+strings.Join(flags, ";"))
+	}
+	if hidden {
+		options = append(options, "hidden=1")
+	}
+	if hvVendorId != nil {
+		options = append(options, "hv-vendor-id="+*hvVendorId)
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	cpu := strings.Join(options, ",")
+	return &cpu
+}
+
+func FormNumaNodeString(cpus string, memory *int64, hostNodes *string, policy *string) *string {
+	numa := "cpus=" + cpus
+	if memory != nil {
+		numa = numa + fmt.Sprintf(",memory=%v", *memory)
+	}
+	if hostNodes != nil {
+		numa = numa + ",hostnodes=" + *hostNodes
+	}
+	if policy != nil {
+		numa = numa + ",policy=" + *policy
+	}
+	return &numa
+}
+
+func AllocateNumaNodeConfig(position int, config *string, input *proxmox.ApplyVirtualMachineConfigurationSyncRequestContent) error {
+	switch position {
+	case 0:
+		input.Numa0 = config
+	case 1:
+		input.Numa1 = config
+	case 2:
+		input.Numa2 = config
+	case 3:
+		input.Numa3 = config
+	case 4:
+		input.Numa4 = config
+	case 5:
+		input.Numa5 = config
+	case 6:
+		input.Numa6 = config
+	case 7:
+		input.Numa7 = config
+	default:
+		return fmt.Errorf("invalid numa node position")
+	}
+	return nil
+}
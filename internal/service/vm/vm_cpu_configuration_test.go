@@ -0,0 +1,57 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineCPUConfiguration(t *testing.T) {
+	sum := proxmox.VirtualMachineConfigurationSummary{
+		Cpu:      proxmox.PtrString("host,flags=+aes;-pcid,hidden=1,hv-vendor-id=proxmox"),
+		Cpulimit: proxmox.PtrFloat32(0.3),
+		Vcpus:    proxmox.PtrFloat32(2),
+		Affinity: proxmox.PtrString("0-3,8"),
+		Numa:     proxmox.PtrFloat32(1),
+		Numa0:    proxmox.PtrString("cpus=0-1,hostnodes=0,memory=1024,policy=bind"),
+		Numa1:    proxmox.PtrString("cpus=2-3;6"),
+	}
+
+	cpu := DetermineCPUConfiguration(sum)
+	assert.Equal(t, "x86_64", cpu.Architecture)
+	assert.Equal(t, "host", *cpu.EmulatedType)
+	assert.Equal(t, []string{"+aes", "-pcid"}, cpu.Flags)
+	assert.True(t, cpu.Hidden)
+	assert.Equal(t, "proxmox", *cpu.HvVendorId)
+	assert.Equal(t, 0.3, *cpu.Limit)
+	assert.Equal(t, 2, *cpu.VCpus)
+	assert.Equal(t, "0-3,8", *cpu.Affinity)
+	assert.True(t, cpu.Numa)
+
+	memory := int64(1024)
+	assert.Equal(t, []VirtualMachineNumaNode{
+		{Position: 0, Cpus: "0-1", Memory: &memory, HostNodes: proxmox.PtrString("0"), Policy: proxmox.PtrString("bind")},
+		{Position: 1, Cpus: "2-3;6"},
+	}, cpu.NumaNodes)
+
+	cpu = DetermineCPUConfiguration(proxmox.VirtualMachineConfigurationSummary{
+		Cpu: proxmox.PtrString("cputype=kvm64"),
+	})
+	assert.Equal(t, "kvm64", *cpu.EmulatedType)
+	assert.Nil(t, cpu.Flags)
+	assert.False(t, cpu.Numa)
+	assert.Nil(t, cpu.NumaNodes)
+}
+
+func TestFormCpuString(t *testing.T) {
+	assert.Equal(t, "cputype=host,flags=+aes;-pcid,hidden=1,hv-vendor-id=proxmox", *FormCpuString(proxmox.PtrString("host"), []string{"+aes", "-pcid"}, true, proxmox.PtrString("proxmox")))
+	assert.Equal(t, "cputype=kvm64", *FormCpuString(proxmox.PtrString("kvm64"), nil, false, nil))
+	assert.Nil(t, FormCpuString(nil, nil, false, nil))
+}
+
+func TestFormNumaNodeString(t *testing.T) {
+	memory := int64(2048)
+	assert.Equal(t, "cpus=0-3,memory=2048,hostnodes=0,policy=bind", *FormNumaNodeString("0-3", &memory, proxmox.PtrString("0"), proxmox.PtrString("bind")))
+	assert.Equal(t, "cpus=4", *FormNumaNodeString("4", nil, nil, nil))
+}
@@ -0,0 +1,159 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultRngMaxBytes   = 1024
+	DefaultRngPeriod     = 1000
+	DefaultWatchdogModel = "i6300esb"
+	DefaultAudioDriver   = "spice"
+)
+
+type VirtualMachineRng struct {
+	Source   string
+	MaxBytes int64
+	Period   int64
+}
+
+type VirtualMachineWatchdog struct {
+	Model  string
+	Action *string
+}
+
+type VirtualMachineAudio struct {
+	Device string
+	Driver string
+}
+
+// the source key is optional when it is the first value, an example of the string is:
+// source=/dev/urandom,max_bytes=1024,period=1000
+func DetermineRngConfig(rng *string) *VirtualMachineRng {
+	if rng == nil {
+		return nil
+	}
+
+	r := VirtualMachineRng{
+		MaxBytes: DefaultRngMaxBytes,
+		Period:   DefaultRngPeriod,
+	}
+
+	for i, option := range strings.Split(*rng, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			if i == 0 {
+				r.Source = option
+			}
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "source":
+			r.Source = value
+		case "max_bytes":
+			b, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				log.Warnf("invalid rng max bytes: %s", value)
+				continue
+			}
+			r.MaxBytes = b
+		case "period":
+			p, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				log.Warnf("invalid rng period: %s", value)
+				continue
+			}
+			r.Period = p
+		default:
+			log.Warnf("unknown rng option: %s", key)
+		}
+	}
+
+	return &r
+}
+
+// the model key is optional when it is the first value, an example of the string is:
+// i6300esb,action=reset
+func DetermineWatchdogConfig(watchdog *string) *VirtualMachineWatchdog {
+	if watchdog == nil {
+		return nil
+	}
+
+	w := VirtualMachineWatchdog{
+		Model: DefaultWatchdogModel,
+	}
+
+	for i, option := range strings.Split(*watchdog, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			if i == 0 {
+				w.Model = option
+			}
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "model":
+			w.Model = value
+		case "action":
+			w.Action = &value
+		default:
+			log.Warnf("unknown watchdog option: %s", key)
+		}
+	}
+
+	return &w
+}
+
+// an example of the string is:
+// device=ich9-intel-hda,driver=spice
+func DetermineAudioConfig(audio *string) *VirtualMachineAudio {
+	if audio == nil {
+		return nil
+	}
+
+	a := VirtualMachineAudio{
+		Driver: DefaultAudioDriver,
+	}
+
+	for _, option := range strings.Split(*audio, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "device":
+			a.Device = value
+		case "driver":
+			a.Driver = value
+		default:
+			log.Warnf("unknown audio option: %s", key)
+		}
+	}
+
+	return &a
+}
+
+func FormRngString(source string, maxBytes int64, period int64) *string {
+	rng := "source=" + source + fmt.Sprintf(",max_bytes=%v,period=%v", maxBytes, period)
+	return &rng
+}
+
+func FormWatchdogString(model string, action *string) *string {
+	watchdog := "model=" + model
+	if action != nil {
+		watchdog = watchdog + ",action=" + *action
+	}
+	return &watchdog
+}
+
+func FormAudioString(device string, driver string) *string {
+	audio := "device=" + device + ",driver=" + driver
+	return &audio
+}
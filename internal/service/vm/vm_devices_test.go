@@ -0,0 +1,43 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineRngConfig(t *testing.T) {
+	assert.Nil(t, DetermineRngConfig(nil))
+
+	rng := DetermineRngConfig(proxmox.PtrString("/dev/hwrng"))
+	assert.Equal(t, &VirtualMachineRng{Source: "/dev/hwrng", MaxBytes: 1024, Period: 1000}, rng)
+
+	rng = DetermineRngConfig(proxmox.PtrString("source=/dev/urandom,max_bytes=0,period=500"))
+	assert.Equal(t, &VirtualMachineRng{Source: "/dev/urandom", MaxBytes: 0, Period: 500}, rng)
+}
+
+func TestDetermineWatchdogConfig(t *testing.T) {
+	assert.Nil(t, DetermineWatchdogConfig(nil))
+
+	watchdog := DetermineWatchdogConfig(proxmox.PtrString("action=poweroff"))
+	assert.Equal(t, "i6300esb", watchdog.Model)
+	assert.Equal(t, "poweroff", *watchdog.Action)
+
+	watchdog = DetermineWatchdogConfig(proxmox.PtrString("ib700"))
+	assert.Equal(t, "ib700", watchdog.Model)
+	assert.Nil(t, watchdog.Action)
+}
+
+func TestDetermineAudioConfig(t *testing.T) {
+	assert.Nil(t, DetermineAudioConfig(nil))
+	assert.Equal(t, &VirtualMachineAudio{Device: "AC97", Driver: "spice"}, DetermineAudioConfig(proxmox.PtrString("device=AC97")))
+	assert.Equal(t, &VirtualMachineAudio{Device: "ich9-intel-hda", Driver: "none"}, DetermineAudioConfig(proxmox.PtrString("device=ich9-intel-hda,driver=none")))
+}
+
+func TestFormDeviceStrings(t *testing.T) {
+	assert.Equal(t, "source=/dev/urandom,max_bytes=1024,period=1000", *FormRngString("/dev/urandom", 1024, 1000))
+	assert.Equal(t, "model=i6300esb,action=reset", *FormWatchdogString("i6300esb", proxmox.PtrString("reset")))
+	assert.Equal(t, "model=ib700", *FormWatchdogString("ib700", nil))
+	assert.Equal(t, "device=intel-hda,driver=spice", *FormAudioString("intel-hda", "spice"))
+}
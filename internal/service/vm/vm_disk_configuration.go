@@ -10,6 +10,11 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	DiskInterfaceEfi = "efidisk"
+	DiskInterfaceTpm = "tpmstate"
+)
+
 type VirtualMachineDisk struct {
 	Storage       string
 	FileFormat    *string
@@ -21,6 +26,10 @@ type VirtualMachineDisk struct {
 	SSDEmulation  bool
 	Discard       bool
 	Name          string
+	// efi disk and tpm state options
+	EfiType         *string
+	PreEnrolledKeys bool
+	TpmVersion      *string
 }
 
 type VirtualMachineDiskSpeedLimits struct {
@@ -68,9 +77,38 @@ func DetermineDiskConfiguration(cfg *proxmox.VirtualMachineConfigurationSummary)
 	}
 	virtualDisks = append(virtualDisks, unusedDisks...)
 
+	efiDisks, err := readDiskMap(cfgMap, DiskInterfaceEfi, 1)
+	if err != nil {
+		return nil, err
+	}
+	virtualDisks = append(virtualDisks, efiDisks...)
+
+	tpmDisks, err := readDiskMap(cfgMap, DiskInterfaceTpm, 1)
+	if err != nil {
+		return nil, err
+	}
+	virtualDisks = append(virtualDisks, tpmDisks...)
+
 	return virtualDisks, nil
 }
 
+// separates the efi disk and tpm state from the disks attached to a bus
+func SplitFirmwareDisks(disks []VirtualMachineDisk) ([]VirtualMachineDisk, *VirtualMachineDisk, *VirtualMachineDisk) {
+	busDisks := []VirtualMachineDisk{}
+	var efiDisk, tpmState *VirtualMachineDisk
+	for i, d := range disks {
+		switch d.InterfaceType {
+		case DiskInterfaceEfi:
+			efiDisk = &disks[i]
+		case DiskInterfaceTpm:
+			tpmState = &disks[i]
+		default:
+			busDisks = append(busDisks, d)
+		}
+	}
+	return busDisks, efiDisk, tpmState
+}
+
 func readDiskMap(m map[string]interface{}, key string, times int) ([]VirtualMachineDisk, error) {
 	virtualDisks := []VirtualMachineDisk{}
 	for i := 0; i < times; i++ {
@@ -134,6 +172,12 @@ func readDiskString(diskString string) (VirtualMachineDisk, error) {
 			}
 		case "format":
 			disk.FileFormat = &value
+		case "efitype":
+			disk.EfiType = &value
+		case "pre-enrolled-keys":
+			disk.PreEnrolledKeys = value == "1"
+		case "version":
+			disk.TpmVersion = &value
 		case "iothread":
 			if value == "1" {
 				disk.UseIOThreads = true
@@ -0,0 +1,32 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DetermineDiskConfiguration_FirmwareDisks(t *testing.T) {
+	cfg := &proxmox.VirtualMachineConfigurationSummary{
+		Scsi0:     proxmox.PtrString("local-lvm:vm-100-disk-0,size=10G"),
+		Efidisk0:  proxmox.PtrString("local-lvm:vm-100-disk-1,efitype=4m,pre-enrolled-keys=1,size=4M"),
+		Tpmstate0: proxmox.PtrString("local-lvm:vm-100-disk-2,size=4M,version=v2.0"),
+	}
+
+	disks, err := DetermineDiskConfiguration(cfg)
+	assert.Nil(t, err)
+	assert.Len(t, disks, 3)
+
+	busDisks, efiDisk, tpmState := SplitFirmwareDisks(disks)
+	assert.Len(t, busDisks, 1)
+	assert.Equal(t, "scsi", busDisks[0].InterfaceType)
+
+	assert.NotNil(t, efiDisk)
+	assert.Equal(t, "local-lvm", efiDisk.Storage)
+	assert.Equal(t, "4m", *efiDisk.EfiType)
+	assert.True(t, efiDisk.PreEnrolledKeys)
+
+	assert.NotNil(t, tpmState)
+	assert.Equal(t, "v2.0", *tpmState.TpmVersion)
+}
@@ -0,0 +1,129 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	log "github.com/sirupsen/logrus"
+)
+
+type VirtualMachineSerialDevice struct {
+	Position int
+	Type     string
+}
+
+type VirtualMachineDisplay struct {
+	Type                string
+	Memory              *int
+	SpiceFolderSharing  bool
+	SpiceVideoStreaming *string
+}
+
+func DetermineSerialDevicesFromConfig(cfg *proxmox.VirtualMachineConfigurationSummary) []VirtualMachineSerialDevice {
+	devices := []VirtualMachineSerialDevice{}
+	for i, s := range []*string{cfg.Serial0, cfg.Serial1, cfg.Serial2, cfg.Serial3} {
+		if s == nil {
+			continue
+		}
+		devices = append(devices, VirtualMachineSerialDevice{
+			Position: i,
+			Type:     *s,
+		})
+	}
+	return devices
+}
+
+func AllocateSerialDeviceConfig(position int, config *string, input *proxmox.ApplyVirtualMachineConfigurationSyncRequestContent) error {
+	switch position {
+	case 0:
+		input.Serial0 = config
+	case 1:
+		input.Serial1 = config
+	case 2:
+		input.Serial2 = config
+	case 3:
+		input.Serial3 = config
+	default:
+		return fmt.Errorf("invalid serial device position")
+	}
+	return nil
+}
+
+// the type key is optional when it is the first value, an example of the string is:
+// qxl,memory=32
+func DetermineDisplayConfig(vga *string, spice *string) *VirtualMachineDisplay {
+	if vga == nil && spice == nil {
+		return nil
+	}
+
+	display := VirtualMachineDisplay{
+		Type: "std",
+	}
+
+	if vga != nil {
+		for i, option := range strings.Split(*vga, ",") {
+			values := strings.SplitN(option, "=", 2)
+			if len(values) != 2 {
+				if i == 0 {
+					display.Type = option
+				}
+				continue
+			}
+			key, value := values[0], values[1]
+			switch key {
+			case "type":
+				display.Type = value
+			case "memory":
+				m, err := strconv.Atoi(value)
+				if err != nil {
+					log.Warnf("invalid display memory: %s", value)
+					continue
+				}
+				display.Memory = &m
+			default:
+				log.Warnf("unknown display option: %s", key)
+			}
+		}
+	}
+
+	if spice != nil {
+		for _, option := range strings.Split(*spice, ",") {
+			values := strings.SplitN(option, "=", 2)
+			if len(values) != 2 {
+				continue
+			}
+			key, value := values[0], values[1]
+			switch key {
+			case "foldersharing":
+				display.SpiceFolderSharing = value == "1"
+			case "videostreaming":
+				display.SpiceVideoStreaming = &value
+			default:
+				log.Warnf("unknown spice option: %s", key)
+			}
+		}
+	}
+
+	return &display
+}
+
+func FormDisplayString(displayType string, memory *int) *string {
+	vga := "type=" + displayType
+	if memory != nil {
+		vga = vga + fmt.Sprintf(",memory=%v", *memory)
+	}
+	return &vga
+}
+
+func FormSpiceEnhancementsString(folderSharing bool, videoStreaming *string) *string {
+	spice := "foldersharing=0"
+	if folderSharing {
+		spice = "foldersharing=1"
+	}
+	if videoStreaming != nil {
+		spice = spice + ",videostreaming=" + *videoStreaming
+	}
+	return &spice
+}
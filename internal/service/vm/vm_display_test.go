@@ -0,0 +1,44 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineSerialDevicesFromConfig(t *testing.T) {
+	cfg := &proxmox.VirtualMachineConfigurationSummary{
+		Serial0: proxmox.PtrString("socket"),
+		Serial2: proxmox.PtrString("/dev/ttyS0"),
+	}
+
+	devices := DetermineSerialDevicesFromConfig(cfg)
+	assert.Equal(t, []VirtualMachineSerialDevice{
+		{Position: 0, Type: "socket"},
+		{Position: 2, Type: "/dev/ttyS0"},
+	}, devices)
+}
+
+func TestDetermineDisplayConfig(t *testing.T) {
+	assert.Nil(t, DetermineDisplayConfig(nil, nil))
+
+	serial := "serial0"
+	display := DetermineDisplayConfig(&serial, nil)
+	assert.Equal(t, "serial0", display.Type)
+	assert.Nil(t, display.Memory)
+
+	qxl := "type=qxl,memory=32"
+	spice := "foldersharing=1,videostreaming=filter"
+	display = DetermineDisplayConfig(&qxl, &spice)
+	assert.Equal(t, "qxl", display.Type)
+	assert.Equal(t, 32, *display.Memory)
+	assert.True(t, display.SpiceFolderSharing)
+	assert.Equal(t, "filter", *display.SpiceVideoStreaming)
+}
+
+func TestFormDisplayString(t *testing.T) {
+	memory := 16
+	assert.Equal(t, "type=std,memory=16", *FormDisplayString("std", &memory))
+	assert.Equal(t, "type=serial0", *FormDisplayString("serial0", nil))
+}
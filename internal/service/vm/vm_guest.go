@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"net"
+	"strings"
+)
+
+type GuestAgentIpAddress struct {
+	Address string `json:"ip-address"`
+	Type    string `json:"ip-address-type"`
+	Prefix  int    `json:"prefix"`
+}
+
+type GuestAgentNetworkInterface struct {
+	Name        string                `json:"name"`
+	MacAddress  *string               `json:"hardware-address,omitempty"`
+	IpAddresses []GuestAgentIpAddress `json:"ip-addresses,omitempty"`
+}
+
+type GuestAgentHostname struct {
+	Hostname string `json:"host-name"`
+}
+
+type GuestAgentOsInfo struct {
+	Id            *string `json:"id,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	PrettyName    *string `json:"pretty-name,omitempty"`
+	Version       *string `json:"version,omitempty"`
+	KernelRelease *string `json:"kernel-release,omitempty"`
+	Machine       *string `json:"machine,omitempty"`
+}
+
+type VirtualMachineGuestNetworkInterface struct {
+	Name          string
+	MacAddress    *string
+	IPv4Addresses []string
+	IPv6Addresses []string
+}
+
+// information reported by the qemu guest agent of a running virtual machine
+type VirtualMachineGuest struct {
+	Hostname          *string
+	OsInfo            *GuestAgentOsInfo
+	NetworkInterfaces []VirtualMachineGuestNetworkInterface
+}
+
+func DetermineGuestNetworkInterfaces(ifaces []GuestAgentNetworkInterface) []VirtualMachineGuestNetworkInterface {
+	interfaces := []VirtualMachineGuestNetworkInterface{}
+	for _, iface := range ifaces {
+		i := VirtualMachineGuestNetworkInterface{
+			Name:          iface.Name,
+			MacAddress:    iface.MacAddress,
+			IPv4Addresses: []string{},
+			IPv6Addresses: []string{},
+		}
+		for _, ip := range iface.IpAddresses {
+			switch ip.Type {
+			case "ipv4":
+				i.IPv4Addresses = append(i.IPv4Addresses, ip.Address)
+			case "ipv6":
+				i.IPv6Addresses = append(i.IPv6Addresses, ip.Address)
+			}
+		}
+		interfaces = append(interfaces, i)
+	}
+	return interfaces
+}
+
+// loopback and link local addresses are not reachable from outside the guest
+func isRoutableAddress(address string) bool {
+	ip := net.ParseIP(strings.Split(address, "%")[0])
+	if ip == nil {
+		return false
+	}
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
+
+func (g *VirtualMachineGuest) IPv4Addresses() []string {
+	addresses := []string{}
+	for _, iface := range g.NetworkInterfaces {
+		for _, address := range iface.IPv4Addresses {
+			if isRoutableAddress(address) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
+}
+
+func (g *VirtualMachineGuest) IPv6Addresses() []string {
+	addresses := []string{}
+	for _, iface := range g.NetworkInterfaces {
+		for _, address := range iface.IPv6Addresses {
+			if isRoutableAddress(address) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
+}
+
+// returns the first routable address on the interface, when set, that falls within the cidr, when set
+func (g *VirtualMachineGuest) FindAddress(iface string, cidr *net.IPNet) *string {
+	for _, i := range g.NetworkInterfaces {
+		if iface != "" && i.Name != iface {
+			continue
+		}
+		addresses := append([]string{}, i.IPv4Addresses...)
+		for _, address := range append(addresses, i.IPv6Addresses...) {
+			if !isRoutableAddress(address) {
+				continue
+			}
+			if cidr != nil && !cidr.Contains(net.ParseIP(address)) {
+				continue
+			}
+			return &address
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestAddresses(t *testing.T) {
+	mac := "bc:24:11:2a:3b:4c"
+	guest := VirtualMachineGuest{
+		NetworkInterfaces: DetermineGuestNetworkInterfaces([]GuestAgentNetworkInterface{
+			{
+				Name: "lo",
+				IpAddresses: []GuestAgentIpAddress{
+					{Address: "127.0.0.1", Type: "ipv4", Prefix: 8},
+					{Address: "::1", Type: "ipv6", Prefix: 128},
+				},
+			},
+			{
+				Name:       "eth0",
+				MacAddress: &mac,
+				IpAddresses: []GuestAgentIpAddress{
+					{Address: "10.0.0.20", Type: "ipv4", Prefix: 24},
+					{Address: "fe80::be24:11ff:fe2a:3b4c", Type: "ipv6", Prefix: 64},
+					{Address: "2001:db8::20", Type: "ipv6", Prefix: 64},
+				},
+			},
+			{
+				Name: "docker0",
+				IpAddresses: []GuestAgentIpAddress{
+					{Address: "172.17.0.1", Type: "ipv4", Prefix: 16},
+				},
+			},
+		}),
+	}
+
+	assert.Equal(t, []string{"127.0.0.1"}, guest.NetworkInterfaces[0].IPv4Addresses)
+	assert.Equal(t, []string{"10.0.0.20", "172.17.0.1"}, guest.IPv4Addresses())
+	assert.Equal(t, []string{"2001:db8::20"}, guest.IPv6Addresses())
+
+	assert.Equal(t, "10.0.0.20", *guest.FindAddress("", nil))
+	assert.Equal(t, "172.17.0.1", *guest.FindAddress("docker0", nil))
+
+	_, cidr, _ := net.ParseCIDR("2001:db8::/32")
+	assert.Equal(t, "2001:db8::20", *guest.FindAddress("eth0", cidr))
+	assert.Nil(t, guest.FindAddress("eth1", nil))
+}
@@ -0,0 +1,47 @@
+package vm
+
+import "strings"
+
+const (
+	HotplugNetwork   = "network"
+	HotplugDisk      = "disk"
+	HotplugCPU       = "cpu"
+	HotplugMemory    = "memory"
+	HotplugUSB       = "usb"
+	HotplugCloudInit = "cloudinit"
+)
+
+// proxmox enables these when hotplug is unset or set to 1
+var DefaultHotplug = []string{HotplugNetwork, HotplugDisk, HotplugUSB}
+
+func DetermineHotplug(h *string) []string {
+	if h == nil {
+		return DefaultHotplug
+	}
+
+	switch *h {
+	case "", "1":
+		return DefaultHotplug
+	case "0":
+		return []string{}
+	}
+
+	features := []string{}
+	for _, f := range strings.Split(*h, ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			features = append(features, f)
+		}
+	}
+
+	return features
+}
+
+func FormHotplugString(features []string) *string {
+	h := "0"
+	if len(features) > 0 {
+		h = strings.Join(features, ",")
+	}
+
+	return &h
+}
@@ -0,0 +1,25 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineHotplug(t *testing.T) {
+	assert.Equal(t, DefaultHotplug, DetermineHotplug(nil))
+
+	enabled := "1"
+	assert.Equal(t, DefaultHotplug, DetermineHotplug(&enabled))
+
+	disabled := "0"
+	assert.Equal(t, []string{}, DetermineHotplug(&disabled))
+
+	features := "network,disk,memory,cpu"
+	assert.Equal(t, []string{"network", "disk", "memory", "cpu"}, DetermineHotplug(&features))
+}
+
+func TestFormHotplugString(t *testing.T) {
+	assert.Equal(t, "0", *FormHotplugString([]string{}))
+	assert.Equal(t, "network,disk", *FormHotplugString([]string{"network", "disk"}))
+}
@@ -1,5 +1,14 @@
 package vm
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	log "github.com/sirupsen/logrus"
+)
+
 type VirtualMachinePCIDevice struct {
 	Name       string
 	ID         string
@@ -9,3 +18,72 @@ type VirtualMachinePCIDevice struct {
 	ROMFile    *string
 	PrimaryGPU bool
 }
+
+func DeterminePCIDevicesFromConfig(cfg *proxmox.VirtualMachineConfigurationSummary) ([]VirtualMachinePCIDevice, error) {
+	var cfgMap map[string]interface{}
+	inrec, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	json.Unmarshal(inrec, &cfgMap)
+
+	devices := []VirtualMachinePCIDevice{}
+	for i := 0; i < 10; i++ {
+		n := fmt.Sprintf("%s%v", "hostpci", i)
+		if val, ok := cfgMap[n]; ok {
+			device, err := readPCIDeviceString(val.(string))
+			if err != nil {
+				return nil, err
+			}
+			device.Name = n
+			devices = append(devices, device)
+		}
+	}
+
+	return devices, nil
+}
+
+func readPCIDeviceString(pciString string) (VirtualMachinePCIDevice, error) {
+	// an example of the string is:
+	// 0000:01:00,pcie=1,x-vga=1,rombar=0
+	// the host key is optional when it is the first value
+	// host=0000:01:00.0,mdev=nvidia-63
+
+	device := VirtualMachinePCIDevice{
+		ROMBAR: true,
+	}
+
+	for i, option := range strings.Split(pciString, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			if i == 0 {
+				device.ID = option
+				continue
+			}
+			return VirtualMachinePCIDevice{}, fmt.Errorf("invalid pci device string: %s", pciString)
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "host":
+			device.ID = value
+		case "pcie":
+			device.PCIE = value == "1"
+		case "x-vga":
+			device.PrimaryGPU = value == "1"
+		case "rombar":
+			device.ROMBAR = value != "0"
+		case "romfile":
+			device.ROMFile = &value
+		case "mdev":
+			device.MDEV = &value
+		default:
+			log.Warnf("unknown pci device option: %s", key)
+		}
+	}
+
+	if device.ID == "" {
+		return VirtualMachinePCIDevice{}, fmt.Errorf("pci device string has no host: %s", pciString)
+	}
+
+	return device, nil
+}
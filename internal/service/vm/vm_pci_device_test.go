@@ -0,0 +1,47 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readPCIDeviceString_Success(t *testing.T) {
+	device, err := readPCIDeviceString("0000:01:00,pcie=1,x-vga=1,rombar=0,romfile=vbios.bin")
+	assert.Nil(t, err)
+	assert.Equal(t, "0000:01:00", device.ID)
+	assert.True(t, device.PCIE)
+	assert.True(t, device.PrimaryGPU)
+	assert.False(t, device.ROMBAR)
+	assert.Equal(t, "vbios.bin", *device.ROMFile)
+	assert.Nil(t, device.MDEV)
+}
+
+func Test_readPCIDeviceString_HostKey(t *testing.T) {
+	device, err := readPCIDeviceString("host=0000:02:00.0,mdev=nvidia-63")
+	assert.Nil(t, err)
+	assert.Equal(t, "0000:02:00.0", device.ID)
+	assert.Equal(t, "nvidia-63", *device.MDEV)
+	assert.True(t, device.ROMBAR)
+	assert.False(t, device.PCIE)
+}
+
+func Test_readPCIDeviceString_MissingHost(t *testing.T) {
+	_, err := readPCIDeviceString("pcie=1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has no host")
+}
+
+func Test_DeterminePCIDevicesFromConfig_Success(t *testing.T) {
+	cfg := &proxmox.VirtualMachineConfigurationSummary{
+		Hostpci0: proxmox.PtrString("0000:01:00,pcie=1"),
+		Hostpci3: proxmox.PtrString("0000:03:00"),
+	}
+
+	devices, err := DeterminePCIDevicesFromConfig(cfg)
+	assert.Nil(t, err)
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "hostpci0", devices[0].Name)
+	assert.Equal(t, "hostpci3", devices[1].Name)
+}
@@ -0,0 +1,128 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	log "github.com/sirupsen/logrus"
+)
+
+const UsbHostSpice = "spice"
+
+type VirtualMachineUSBDevice struct {
+	Position int
+	HostId   *string
+	HostPort *string
+	Spice    bool
+	Mapping  *string
+	USB3     bool
+}
+
+func DetermineUSBDevicesFromConfig(cfg *proxmox.VirtualMachineConfigurationSummary) []VirtualMachineUSBDevice {
+	devices := []VirtualMachineUSBDevice{}
+	usb := []*string{cfg.Usb0, cfg.Usb1, cfg.Usb2, cfg.Usb3, cfg.Usb4, cfg.Usb5, cfg.Usb6, cfg.Usb7, cfg.Usb8, cfg.Usb9, cfg.Usb10, cfg.Usb11, cfg.Usb12, cfg.Usb13}
+	for i, u := range usb {
+		if u == nil {
+			continue
+		}
+		device := readUSBDeviceString(*u)
+		device.Position = i
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// the host key is optional when it is the first value, an example of the string is:
+// host=1a86:7523,usb3=1
+// the host can also be a bus-port path, such as 1-2.3, or spice
+// mapping=zigbee
+func readUSBDeviceString(usbString string) VirtualMachineUSBDevice {
+	device := VirtualMachineUSBDevice{}
+
+	for i, option := range strings.Split(usbString, ",") {
+		values := strings.SplitN(option, "=", 2)
+		if len(values) != 2 {
+			if i == 0 {
+				setUSBHost(&device, option)
+			}
+			continue
+		}
+		key, value := values[0], values[1]
+		switch key {
+		case "host":
+			setUSBHost(&device, value)
+		case "mapping":
+			device.Mapping = &value
+		case "usb3":
+			device.USB3 = value == "1"
+		default:
+			log.Warnf("unknown usb device option: %s", key)
+		}
+	}
+
+	return device
+}
+
+func setUSBHost(device *VirtualMachineUSBDevice, host string) {
+	switch {
+	case host == UsbHostSpice:
+		device.Spice = true
+	case strings.Contains(host, ":"):
+		device.HostId = &host
+	default:
+		device.HostPort = &host
+	}
+}
+
+func FormUSBDeviceString(host *string, mapping *string, usb3 bool) *string {
+	options := []string{}
+	if host != nil {
+		options = append(options, "host="+*host)
+	}
+	if mapping != nil {
+		options = append(options, "mapping="+*mapping)
+	}
+	if usb3 {
+		options = append(options, "usb3=1")
+	}
+
+	usb := strings.Join(options, ",")
+	return &usb
+}
+
+func AllocateUSBDeviceConfig(position int, config *string, input *proxmox.ApplyVirtualMachineConfigurationSyncRequestContent) error {
+	switch position {
+	case 0:
+		input.Usb0 = config
+	case 1:
+		input.Usb1 = config
+	case 2:
+		input.Usb2 = config
+	case 3:
+		input.Usb3 = config
+	case 4:
+		input.Usb4 = config
+	case 5:
+		input.Usb5 = config
+	case 6:
+		input.Usb6 = config
+	case 7:
+		input.Usb7 = config
+	case 8:
+		input.Usb8 = config
+	case 9:
+		input.Usb9 = config
+	case 10:
+		input.Usb10 = config
+	case 11:
+		input.Usb11 = config
+	case 12:
+		input.Usb12 = config
+	case 13:
+		input.Usb13 = config
+	default:
+		return fmt.Errorf("invalid usb device position")
+	}
+	return nil
+}
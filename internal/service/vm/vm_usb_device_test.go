@@ -0,0 +1,31 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineUSBDevicesFromConfig(t *testing.T) {
+	cfg := &proxmox.VirtualMachineConfigurationSummary{
+		Usb0: proxmox.PtrString("host=1a86:7523,usb3=1"),
+		Usb1: proxmox.PtrString("1-2.3"),
+		Usb3: proxmox.PtrString("spice"),
+		Usb5: proxmox.PtrString("mapping=zigbee"),
+	}
+
+	devices := DetermineUSBDevicesFromConfig(cfg)
+	assert.Equal(t, []VirtualMachineUSBDevice{
+		{Position: 0, HostId: proxmox.PtrString("1a86:7523"), USB3: true},
+		{Position: 1, HostPort: proxmox.PtrString("1-2.3")},
+		{Position: 3, Spice: true},
+		{Position: 5, Mapping: proxmox.PtrString("zigbee")},
+	}, devices)
+}
+
+func TestFormUSBDeviceString(t *testing.T) {
+	assert.Equal(t, "host=1a86:7523,usb3=1", *FormUSBDeviceString(proxmox.PtrString("1a86:7523"), nil, true))
+	assert.Equal(t, "host=spice", *FormUSBDeviceString(proxmox.PtrString(UsbHostSpice), nil, false))
+	assert.Equal(t, "mapping=zigbee", *FormUSBDeviceString(nil, proxmox.PtrString("zigbee"), false))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type BackupVolume struct {
+	VolumeId string `json:"volid"`
+	VmId     *int   `json:"vmid,omitempty"`
+	Format   string `json:"format"`
+	Size     int64  `json:"size"`
+	Created  int64  `json:"ctime"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+type BackupVirtualMachineInput struct {
+	Node        string
+	VmId        int
+	Storage     string
+	Mode        string
+	Compression string
+	Notes       *string
+}
+
+// starts a vzdump of the virtual machine and returns the task id
+func (c *Proxmox) BackupVirtualMachine(ctx context.Context, input *BackupVirtualMachineInput) (string, error) {
+	path := fmt.Sprintf("/nodes/%s/vzdump", input.Node)
+
+	body := map[string]interface{}{
+		"vmid":    strconv.Itoa(input.VmId),
+		"storage": input.Storage,
+		"mode":    input.Mode,
+		"remove":  0,
+	}
+	if input.Compression == "none" {
+		body["compress"] = "0"
+	} else if input.Compression != "" {
+		body["compress"] = input.Compression
+	}
+	if input.Notes != nil {
+		body["notes-template"] = *input.Notes
+	}
+
+	var upid string
+	err := c.request(ctx, http.MethodPost, path, nil, body, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
+
+func (c *Proxmox) ListVirtualMachineBackups(ctx context.Context, node string, storage string, vmid int) ([]BackupVolume, error) {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content", node, storage)
+	query := url.Values{}
+	query.Set("content", "backup")
+	query.Set("vmid", strconv.Itoa(vmid))
+
+	var volumes []BackupVolume
+	err := c.request(ctx, http.MethodGet, path, query, nil, &volumes)
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+func (c *Proxmox) UpdateBackupNotes(ctx context.Context, node string, storage string, volid string, notes string) error {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, url.PathEscape(volid))
+	body := map[string]interface{}{
+		"notes": notes,
+	}
+
+	return c.request(ctx, http.MethodPut, path, nil, body, nil)
+}
+
+// removes the archive, storages that remove it in a task return the task id
+func (c *Proxmox) DeleteBackup(ctx context.Context, node string, storage string, volid string) (*string, error) {
+	path := fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, url.PathEscape(volid))
+
+	var upid *string
+	err := c.request(ctx, http.MethodDelete, path, nil, nil, &upid)
+	if err != nil {
+		return nil, err
+	}
+
+	return upid, nil
+}
@@ -17,8 +17,18 @@ type VirtualMachine struct {
 	Bios              proxmox.VirtualMachineBios
 	CPU               vm.VirtualMachineCpu
 	Disks             []vm.VirtualMachineDisk
+	EfiDisk           *vm.VirtualMachineDisk
+	TpmState          *vm.VirtualMachineDisk
 	NetworkInterfaces []vm.VirtualMachineNetworkInterface
 	PCIDevices        []vm.VirtualMachinePCIDevice
+	SerialDevices     []vm.VirtualMachineSerialDevice
+	USBDevices        []vm.VirtualMachineUSBDevice
+	Display           *vm.VirtualMachineDisplay
+	Rng               *vm.VirtualMachineRng
+	Watchdog          *vm.VirtualMachineWatchdog
+	Audio             *vm.VirtualMachineAudio
+	Hotplug           []string
+	BootOrder         []string
 	Memory            vm.VirtualMachineMemory
 	CloudInit         *vm.VirtualMachineCloudInit
 	OsType            *proxmox.VirtualMachineOperatingSystem
@@ -26,6 +36,8 @@ type VirtualMachine struct {
 	KVMArguments      *string
 	StartOnBoot       bool
 	KeyboardLayout    *proxmox.VirtualMachineKeyboard
+	Guest             *vm.VirtualMachineGuest
+	Template          bool
 }
 
 func (c *Proxmox) DescribeVirtualMachines(ctx context.Context, node string) ([]*VirtualMachine, error) {
@@ -69,13 +81,22 @@ func (c *Proxmox) DescribeVirtualMachine(ctx context.Context, node string, vmid
 		Tags:           StringSemiColonPtrListToSlice(configSummary.Tags),
 		Name:           configSummary.Name,
 		StartOnBoot:    BooleanIntegerConversion(configSummary.Onboot),
+		Template:       BooleanIntegerConversion(configSummary.Template),
+		Hotplug:        vm.DetermineHotplug(configSummary.Hotplug),
+		BootOrder:      vm.DetermineBootOrder(configSummary.Boot),
+		SerialDevices:  vm.DetermineSerialDevicesFromConfig(configSummary),
+		USBDevices:     vm.DetermineUSBDevicesFromConfig(configSummary),
+		Display:        vm.DetermineDisplayConfig(configSummary.Vga, configSummary.SpiceEnhancements),
+		Rng:            vm.DetermineRngConfig(configSummary.Rng0),
+		Watchdog:       vm.DetermineWatchdogConfig(configSummary.Watchdog),
+		Audio:          vm.DetermineAudioConfig(configSummary.Audio0),
 	}
 
 	diskConfig, err := vm.DetermineDiskConfiguration(configSummary)
 	if err != nil {
 		return nil, err
 	}
-	config.Disks = diskConfig
+	config.Disks, config.EfiDisk, config.TpmState = vm.SplitFirmwareDisks(diskConfig)
 
 	networkConfig, err := vm.DetermineNetworkDevicesFromConfig(configSummary)
 	if err != nil {
@@ -83,5 +104,13 @@ func (c *Proxmox) DescribeVirtualMachine(ctx context.Context, node string, vmid
 	}
 	config.NetworkInterfaces = networkConfig
 
+	pciDevices, err := vm.DeterminePCIDevicesFromConfig(configSummary)
+	if err != nil {
+		return nil, err
+	}
+	config.PCIDevices = pciDevices
+
+	config.Guest = c.determineGuest(ctx, node, vmid, config.Agent)
+
 	return config, nil
 }
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+)
+
+const (
+	minVirtualMachineId = 100
+	maxVirtualMachineId = 999999999
+	// how many ids are checked against the cluster before giving up on a reservation
+	maxVirtualMachineIdProbes = 100
+)
+
+func (c *Proxmox) GetNextVirtualMachineId(ctx context.Context) (int, error) {
+	var id json.Number
+	err := c.request(ctx, http.MethodGet, "/cluster/nextid", nil, nil, &id)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(id.String())
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (c *Proxmox) IsVirtualMachineIdAvailable(ctx context.Context, vmId int) (bool, error) {
+	query := url.Values{}
+	query.Set("vmid", strconv.Itoa(vmId))
+
+	var id json.Number
+	err := c.request(ctx, http.MethodGet, "/cluster/nextid", query, nil, &id)
+	if err != nil {
+		if pe, ok := err.(*errors.ProxmoxError); ok && pe.StatusCode == http.StatusBadRequest {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// hands out virtual machine ids, ids are held until released so that parallel creates
+// within the provider never receive the same id from the cluster
+type vmIdAllocator struct {
+	mu       sync.Mutex
+	reserved map[int]bool
+}
+
+func newVmIdAllocator() *vmIdAllocator {
+	return &vmIdAllocator{
+		reserved: map[int]bool{},
+	}
+}
+
+type ReserveVirtualMachineIdInput struct {
+	Start *int
+	End   *int
+}
+
+func (c *Proxmox) ReserveVirtualMachineId(ctx context.Context, input *ReserveVirtualMachineIdInput) (int, error) {
+	start := minVirtualMachineId
+	end := maxVirtualMachineId
+	if input.Start != nil {
+		start = *input.Start
+	} else {
+		next, err := c.GetNextVirtualMachineId(ctx)
+		if err != nil {
+			return 0, err
+		}
+		start = next
+	}
+	if input.End != nil {
+		end = *input.End
+	}
+	if start > end {
+		return 0, fmt.Errorf("invalid id range %d-%d", start, end)
+	}
+
+	id := start
+	for probes := 0; probes < maxVirtualMachineIdProbes; probes++ {
+		// the candidate is held while it is checked, so parallel creates move on to the next id
+		// instead of waiting for the lock
+		candidate, ok := c.ids.hold(id, end)
+		if !ok {
+			break
+		}
+		available, err := c.IsVirtualMachineIdAvailable(ctx, candidate)
+		if err != nil || !available {
+			c.ReleaseVirtualMachineId(candidate)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if available {
+			return candidate, nil
+		}
+		id = candidate + 1
+	}
+
+	return 0, fmt.Errorf("no available id found in range %d-%d after checking %d ids", start, end, maxVirtualMachineIdProbes)
+}
+
+// reserves the first id from start to end that isn't already held
+func (a *vmIdAllocator) hold(start int, end int) (int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id := start; id <= end; id++ {
+		if !a.reserved[id] {
+			a.reserved[id] = true
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Proxmox) ReleaseVirtualMachineId(vmId int) {
+	c.ids.mu.Lock()
+	defer c.ids.mu.Unlock()
+	delete(c.ids.reserved, vmId)
+}
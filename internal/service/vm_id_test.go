@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ids below 105 are taken in the cluster
+func newTestIdClient(t *testing.T) *Proxmox {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		vmid, err := strconv.Atoi(r.URL.Query().Get("vmid"))
+		if err != nil || vmid >= 105 {
+			w.Write([]byte(`{"data":"105"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"data":null,"errors":{"vmid":"VM already exists"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(ClientConfig{Endpoint: server.URL, Token: "test@pve!test=token"})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestReserveVirtualMachineId(t *testing.T) {
+	ctx := context.Background()
+	c := newTestIdClient(t)
+
+	start := 100
+	id, err := c.ReserveVirtualMachineId(ctx, &ReserveVirtualMachineIdInput{Start: &start})
+	assert.NoError(t, err)
+	assert.Equal(t, 105, id)
+
+	// held ids are skipped until they are released
+	next, err := c.ReserveVirtualMachineId(ctx, &ReserveVirtualMachineIdInput{Start: &start})
+	assert.NoError(t, err)
+	assert.Equal(t, 106, next)
+
+	c.ReleaseVirtualMachineId(id)
+	id, err = c.ReserveVirtualMachineId(ctx, &ReserveVirtualMachineIdInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 105, id)
+
+	end := 104
+	_, err = c.ReserveVirtualMachineId(ctx, &ReserveVirtualMachineIdInput{Start: &start, End: &end})
+	assert.Error(t, err)
+	assert.False(t, c.ids.reserved[100])
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type MigrateVirtualMachineInput struct {
+	Node           string
+	VmId           int
+	Target         string
+	Online         bool
+	WithLocalDisks bool
+	TargetStorage  *string
+	StorageMapping map[string]string
+}
+
+// proxmox accepts either a single storage for every disk or a list of source:target pairs
+func FormTargetStorageString(input *MigrateVirtualMachineInput) *string {
+	if input.TargetStorage != nil {
+		return input.TargetStorage
+	}
+	if len(input.StorageMapping) == 0 {
+		return nil
+	}
+
+	pairs := []string{}
+	for source, target := range input.StorageMapping {
+		pairs = append(pairs, source+":"+target)
+	}
+	sort.Strings(pairs)
+
+	mapping := strings.Join(pairs, ",")
+	return &mapping
+}
+
+// starts the migration and returns the task id
+func (c *Proxmox) MigrateVirtualMachine(ctx context.Context, input *MigrateVirtualMachineInput) (string, error) {
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/migrate", input.Node, input.VmId)
+
+	body := map[string]interface{}{
+		"target": input.Target,
+	}
+	if input.Online {
+		body["online"] = 1
+	}
+	if input.WithLocalDisks {
+		body["with-local-disks"] = 1
+	}
+	targetStorage := FormTargetStorageString(input)
+	if targetStorage != nil {
+		body["targetstorage"] = *targetStorage
+	}
+
+	var upid string
+	err := c.request(ctx, http.MethodPost, path, nil, body, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
+
+// returns the storages of the node that are not shared with the rest of the cluster
+func (c *Proxmox) ListNodeLocalStorage(ctx context.Context, node string) ([]string, error) {
+	storages, err := c.ListNodeStorage(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	local := []string{}
+	for _, s := range storages {
+		if s.Shared == nil || *s.Shared == 0 {
+			local = append(local, s.Storage)
+		}
+	}
+
+	return local, nil
+}
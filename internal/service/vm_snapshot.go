@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+)
+
+// the snapshot list always contains an entry for the running state of the virtual machine
+const currentSnapshotName = "current"
+
+type VirtualMachineSnapshot struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Parent      *string `json:"parent,omitempty"`
+	SnapTime    *int64  `json:"snaptime,omitempty"`
+	VmState     int     `json:"vmstate,omitempty"`
+}
+
+type CreateVirtualMachineSnapshotInput struct {
+	Node        string
+	VmId        int
+	Name        string
+	Description *string
+	VmState     bool
+}
+
+func snapshotPath(node string, vmid int, name string) string {
+	return fmt.Sprintf("/nodes/%s/qemu/%d/snapshot/%s", node, vmid, url.PathEscape(name))
+}
+
+func (c *Proxmox) ListVirtualMachineSnapshots(ctx context.Context, node string, vmid int) ([]VirtualMachineSnapshot, error) {
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/snapshot", node, vmid)
+
+	var snapshots []VirtualMachineSnapshot
+	err := c.request(ctx, http.MethodGet, path, nil, nil, &snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []VirtualMachineSnapshot{}
+	for _, s := range snapshots {
+		if s.Name != currentSnapshotName {
+			result = append(result, s)
+		}
+	}
+
+	return result, nil
+}
+
+// starts the snapshot and returns the task id
+func (c *Proxmox) CreateVirtualMachineSnapshot(ctx context.Context, input *CreateVirtualMachineSnapshotInput) (string, error) {
+	vmId := strconv.Itoa(input.VmId)
+	content := proxmox.CreateSnapshotRequestContent{
+		Snapname:    input.Name,
+		Description: input.Description,
+	}
+	if input.VmState {
+		vmState := float32(1)
+		content.Vmstate = &vmState
+	}
+
+	request := c.client.CreateSnapshot(ctx, input.Node, vmId)
+	request = request.CreateSnapshotRequestContent(content)
+	resp, h, err := c.client.CreateSnapshotExecute(request)
+	if err != nil {
+		return "", errors.ApiError(h, err)
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Proxmox) UpdateVirtualMachineSnapshotDescription(ctx context.Context, node string, vmid int, name string, description string) error {
+	body := map[string]interface{}{
+		"description": description,
+	}
+
+	return c.request(ctx, http.MethodPut, snapshotPath(node, vmid, name)+"/config", nil, body, nil)
+}
+
+// starts the rollback and returns the task id
+func (c *Proxmox) RollbackVirtualMachineSnapshot(ctx context.Context, node string, vmid int, name string) (string, error) {
+	var upid string
+	err := c.request(ctx, http.MethodPost, snapshotPath(node, vmid, name)+"/rollback", nil, map[string]interface{}{}, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
+
+// starts the snapshot removal and returns the task id
+func (c *Proxmox) DeleteVirtualMachineSnapshot(ctx context.Context, node string, vmid int, name string) (string, error) {
+	var upid string
+	err := c.request(ctx, http.MethodDelete, snapshotPath(node, vmid, name), nil, nil, &upid)
+	if err != nil {
+		return "", err
+	}
+
+	return upid, nil
+}
+
+func (c *Proxmox) FreezeVirtualMachineFilesystems(ctx context.Context, node string, vmid int) error {
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/agent/fsfreeze-freeze", node, vmid)
+	return c.request(ctx, http.MethodPost, path, nil, map[string]interface{}{}, nil)
+}
+
+func (c *Proxmox) ThawVirtualMachineFilesystems(ctx context.Context, node string, vmid int) error {
+	path := fmt.Sprintf("/nodes/%s/qemu/%d/agent/fsfreeze-thaw", node, vmid)
+	return c.request(ctx, http.MethodPost, path, nil, map[string]interface{}{}, nil)
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/awlsring/proxmox-go/proxmox"
 	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func (c *Proxmox) GetVirtualMachineStatus(ctx context.Context, node string, vmid int) (*proxmox.VirtualMachineStatusSummary, error) {
@@ -19,6 +23,25 @@ func (c *Proxmox) GetVirtualMachineStatus(ctx context.Context, node string, vmid
 	return &r.Data, nil
 }
 
+// polls the status until the virtual machine is no longer locked, such as by a running clone, configuration or snapshot
+func (c *Proxmox) WaitForVirtualMachineLock(ctx context.Context, node string, vmid int, timeout int64) error {
+	tflog.Debug(ctx, "waiting lock to release...")
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		status, err := c.GetVirtualMachineStatus(ctx, node, vmid)
+		if err != nil {
+			tflog.Error(ctx, "error: "+err.Error())
+		} else if !status.HasLock() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for lock to release")
+		}
+		tflog.Debug(ctx, "lock is still active, waiting 5 seconds...")
+		time.Sleep(5 * time.Second)
+	}
+}
+
 func (c *Proxmox) StartVirtualMachine(ctx context.Context, node string, vmid int) error {
 	vmId := strconv.Itoa(vmid)
 	request := c.client.StartVirtualMachine(ctx, node, vmId)
@@ -40,3 +63,59 @@ func (c *Proxmox) StopVirtualMachine(ctx context.Context, node string, vmid int)
 
 	return nil
 }
+
+func (c *Proxmox) ShutdownVirtualMachine(ctx context.Context, node string, vmid int, timeout int64) error {
+	vmId := strconv.Itoa(vmid)
+	t := float32(timeout)
+	request := c.client.ShutdownVirtualMachine(ctx, node, vmId)
+	request = request.ShutdownVirtualMachineRequestContent(proxmox.ShutdownVirtualMachineRequestContent{
+		Timeout: &t,
+	})
+	_, h, err := c.client.ShutdownVirtualMachineExecute(request)
+	if err != nil {
+		return errors.ApiError(h, err)
+	}
+
+	return nil
+}
+
+// returns the upid of the reboot task, proxmox shuts the guest down and starts it again within the timeout
+func (c *Proxmox) RebootVirtualMachine(ctx context.Context, node string, vmid int, timeout int64) (string, error) {
+	vmId := strconv.Itoa(vmid)
+	t := float32(timeout)
+	request := c.client.RebootVirtualMachine(ctx, node, vmId)
+	request = request.RebootVirtualMachineRequestContent(proxmox.RebootVirtualMachineRequestContent{
+		Timeout: &t,
+	})
+	resp, h, err := c.client.RebootVirtualMachineExecute(request)
+	if err != nil {
+		return "", errors.ApiError(h, err)
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Proxmox) PingVirtualMachineAgent(ctx context.Context, node string, vmid int) error {
+	vmId := strconv.Itoa(vmid)
+	request := c.client.PingVirtualMachine(ctx, node, vmId)
+	_, h, err := c.client.PingVirtualMachineExecute(request)
+	if err != nil {
+		return errors.ApiError(h, err)
+	}
+
+	return nil
+}
+
+func (c *Proxmox) SetVirtualMachineBootOrder(ctx context.Context, node string, vmid int, devices []string) error {
+	vmId := strconv.Itoa(vmid)
+	request := c.client.ApplyVirtualMachineConfigurationSync(ctx, node, vmId)
+	request = request.ApplyVirtualMachineConfigurationSyncRequestContent(proxmox.ApplyVirtualMachineConfigurationSyncRequestContent{
+		Boot: vm.FormBootOrderString(devices),
+	})
+	h, err := c.client.ApplyVirtualMachineConfigurationSyncExecute(request)
+	if err != nil {
+		return errors.ApiError(h, err)
+	}
+
+	return nil
+}
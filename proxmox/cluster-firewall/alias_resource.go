@@ -0,0 +1,215 @@
+package cluster_firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &aliasResource{}
+	_ resource.ResourceWithConfigure   = &aliasResource{}
+	_ resource.ResourceWithImportState = &aliasResource{}
+)
+
+func AliasResource() resource.Resource {
+	return &aliasResource{}
+}
+
+type aliasResource struct {
+	client *service.Proxmox
+}
+
+func (r *aliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_alias"
+}
+
+func (r *aliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = aliasResourceSchema
+}
+
+func (r *aliasResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+// returns nil when the alias no longer exists
+func (r *aliasResource) readAliasModel(ctx context.Context, name string) (*aliasModel, error) {
+	alias, err := r.client.DescribeFirewallAlias(ctx, service.ClusterFirewallPath, name)
+	if err != nil {
+		return nil, err
+	}
+	if alias == nil {
+		return nil, nil
+	}
+
+	return &aliasModel{
+		ID:      types.StringValue(name),
+		Name:    types.StringValue(name),
+		CIDR:    types.StringValue(alias.CIDR),
+		Comment: fw.OptionalString(alias.Comment),
+	}, nil
+}
+
+func (r *aliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create cluster firewall alias method")
+	var plan aliasModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateFirewallAlias(ctx, service.ClusterFirewallPath, plan.alias())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating alias",
+			"Could not create cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readAliasModel(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Could not read cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Alias was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *aliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read cluster firewall alias method")
+	var state aliasModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readAliasModel(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Could not read cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Alias '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *aliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update cluster firewall alias method")
+	var plan aliasModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateFirewallAlias(ctx, service.ClusterFirewallPath, plan.alias())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating alias",
+			"Could not update cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readAliasModel(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Could not read cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Alias no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *aliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete cluster firewall alias method")
+	var state aliasModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteFirewallAlias(ctx, service.ClusterFirewallPath, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting alias",
+			"Could not delete cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	m, err := r.readAliasModel(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			"Could not read cluster firewall alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			fmt.Sprintf("Alias '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
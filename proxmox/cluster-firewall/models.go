@@ -0,0 +1,109 @@
+package cluster_firewall
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// the cluster has a single set of firewall options
+const optionsId = "cluster"
+
+type logRateLimitModel struct {
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Burst   types.Int64  `tfsdk:"burst"`
+	Rate    types.String `tfsdk:"rate"`
+}
+
+type optionsModel struct {
+	ID           types.String       `tfsdk:"id"`
+	Enabled      types.Bool         `tfsdk:"enabled"`
+	PolicyIn     types.String       `tfsdk:"policy_in"`
+	PolicyOut    types.String       `tfsdk:"policy_out"`
+	Ebtables     types.Bool         `tfsdk:"ebtables"`
+	LogRateLimit *logRateLimitModel `tfsdk:"log_ratelimit"`
+}
+
+type securityGroupModel struct {
+	ID      types.String   `tfsdk:"id"`
+	Name    types.String   `tfsdk:"name"`
+	Comment types.String   `tfsdk:"comment"`
+	Rules   []fw.RuleModel `tfsdk:"rules"`
+}
+
+type ipSetModel struct {
+	ID      types.String         `tfsdk:"id"`
+	Name    types.String         `tfsdk:"name"`
+	Comment types.String         `tfsdk:"comment"`
+	Entries []fw.IPSetEntryModel `tfsdk:"entries"`
+}
+
+type aliasModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	CIDR    types.String `tfsdk:"cidr"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+func boolOption(i *int, d bool) types.Bool {
+	if i == nil {
+		return types.BoolValue(d)
+	}
+	return types.BoolValue(service.IntToBool(*i))
+}
+
+func stringOption(s *string, d string) types.String {
+	if s == nil {
+		return types.StringValue(d)
+	}
+	return types.StringValue(*s)
+}
+
+// options proxmox does not return are at their defaults, the log rate limit is only tracked once it is managed
+func OptionsToModel(o *service.ClusterFirewallOptions, state *optionsModel) optionsModel {
+	m := optionsModel{
+		ID:        types.StringValue(optionsId),
+		Enabled:   boolOption(o.Enable, false),
+		PolicyIn:  stringOption(o.PolicyIn, "DROP"),
+		PolicyOut: stringOption(o.PolicyOut, "ACCEPT"),
+		Ebtables:  boolOption(o.Ebtables, true),
+	}
+	if state != nil && state.LogRateLimit != nil {
+		l := service.DetermineFirewallLogRateLimit(o.LogRateLimit)
+		m.LogRateLimit = &logRateLimitModel{
+			Enabled: types.BoolValue(l.Enabled),
+			Burst:   types.Int64Value(int64(l.Burst)),
+			Rate:    types.StringValue(l.Rate),
+		}
+	}
+	return m
+}
+
+func (m *optionsModel) options() map[string]interface{} {
+	options := map[string]interface{}{
+		"enable":     service.BoolToInt(m.Enabled.ValueBool()),
+		"policy_in":  m.PolicyIn.ValueString(),
+		"policy_out": m.PolicyOut.ValueString(),
+		"ebtables":   service.BoolToInt(m.Ebtables.ValueBool()),
+	}
+	if m.LogRateLimit != nil {
+		options["log_ratelimit"] = service.FormFirewallLogRateLimit(&service.FirewallLogRateLimit{
+			Enabled: m.LogRateLimit.Enabled.ValueBool(),
+			Burst:   int(m.LogRateLimit.Burst.ValueInt64()),
+			Rate:    m.LogRateLimit.Rate.ValueString(),
+		})
+	}
+	return options
+}
+
+// options reset when the resource is deleted
+var optionKeys = []string{"enable", "policy_in", "policy_out", "ebtables", "log_ratelimit"}
+
+func (m *aliasModel) alias() *service.FirewallAlias {
+	comment := m.Comment.ValueString()
+	return &service.FirewallAlias{
+		Name:    m.Name.ValueString(),
+		CIDR:    m.CIDR.ValueString(),
+		Comment: &comment,
+	}
+}
@@ -0,0 +1,177 @@
+package cluster_firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &optionsResource{}
+	_ resource.ResourceWithConfigure   = &optionsResource{}
+	_ resource.ResourceWithImportState = &optionsResource{}
+)
+
+func OptionsResource() resource.Resource {
+	return &optionsResource{}
+}
+
+type optionsResource struct {
+	client *service.Proxmox
+}
+
+func (r *optionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_firewall_options"
+}
+
+func (r *optionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = optionsResourceSchema
+}
+
+func (r *optionsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *optionsResource) readOptionsModel(ctx context.Context, state *optionsModel) (*optionsModel, error) {
+	options, err := r.client.GetClusterFirewallOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := OptionsToModel(options, state)
+	return &m, nil
+}
+
+func (r *optionsResource) apply(ctx context.Context, plan *optionsModel) (*optionsModel, error) {
+	err := r.client.UpdateFirewallOptions(ctx, service.ClusterFirewallPath, plan.options(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.readOptionsModel(ctx, plan)
+}
+
+func (r *optionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create cluster firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure cluster firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read cluster firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read cluster firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update cluster firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure cluster firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// resets the options to their defaults, which disables the firewall of the cluster
+func (r *optionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete cluster firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateFirewallOptions(ctx, service.ClusterFirewallPath, nil, optionKeys)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resetting firewall options",
+			"Could not reset cluster firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *optionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != optionsId {
+		resp.Diagnostics.AddError(
+			"Error importing firewall options",
+			fmt.Sprintf("Expected import identifier `%s`, got: %s", optionsId, req.ID),
+		)
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read cluster firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,176 @@
+package cluster_firewall
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func idAttribute(description string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func nameAttribute(description string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Required:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(fw.NameRegex, "name must start with a letter and only contain letters, numbers, `-` or `_`"),
+		},
+	}
+}
+
+var optionsResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": idAttribute("The id of the cluster firewall options, always `cluster`."),
+		"enabled": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether the firewall is enabled for the cluster. Rules of nodes and virtual machines only apply while it is enabled.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(true),
+			},
+		},
+		"policy_in": rs.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The policy for incoming traffic that matches no rule.",
+			PlanModifiers: []planmodifier.String{
+				defaults.DefaultString("DROP"),
+			},
+			Validators: []validator.String{
+				stringvalidator.OneOf(fw.Policies...),
+			},
+		},
+		"policy_out": rs.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The policy for outgoing traffic that matches no rule.",
+			PlanModifiers: []planmodifier.String{
+				defaults.DefaultString("ACCEPT"),
+			},
+			Validators: []validator.String{
+				stringvalidator.OneOf(fw.Policies...),
+			},
+		},
+		"ebtables": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to enable ebtables rules for the cluster.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(true),
+			},
+		},
+		"log_ratelimit": rs.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Limits how fast firewall log messages are written.",
+			Attributes: map[string]rs.Attribute{
+				"enabled": rs.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether the rate limit is enabled.",
+					PlanModifiers: []planmodifier.Bool{
+						defaults.DefaultBool(true),
+					},
+				},
+				"burst": rs.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The number of messages logged before the rate applies.",
+					PlanModifiers: []planmodifier.Int64{
+						defaults.DefaultInt64(5),
+					},
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+				"rate": rs.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The rate messages are logged at, such as `1/second`.",
+					PlanModifiers: []planmodifier.String{
+						defaults.DefaultString("1/second"),
+					},
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[1-9][0-9]*/(second|minute|hour|day)$`), "rate must be formatted as `{count}/{second|minute|hour|day}`"),
+					},
+				},
+			},
+		},
+	},
+}
+
+var securityGroupResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":   idAttribute("The id of the security group, the same as its name."),
+		"name": nameAttribute("The name of the security group, referenced as the action of rules of type `group`."),
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the security group.",
+		},
+		"rules": rs.ListNestedAttribute{
+			Optional:    true,
+			Description: "The rules of the security group, in the order they are evaluated.",
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: fw.RuleAttributes,
+			},
+		},
+	},
+}
+
+var ipSetResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":   idAttribute("The id of the ip set, the same as its name."),
+		"name": nameAttribute("The name of the ip set, referenced in rules as `+{name}`."),
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the ip set.",
+		},
+		"entries": rs.SetNestedAttribute{
+			Optional:    true,
+			Description: "The entries of the ip set.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: fw.IPSetEntryAttributes,
+			},
+		},
+	},
+}
+
+var aliasResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":   idAttribute("The id of the alias, the same as its name."),
+		"name": nameAttribute("The name of the alias."),
+		"cidr": rs.StringAttribute{
+			Required:    true,
+			Description: "The address or CIDR the alias stands for.",
+		},
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the alias.",
+		},
+	},
+}
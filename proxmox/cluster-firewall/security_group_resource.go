@@ -0,0 +1,268 @@
+package cluster_firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &securityGroupResource{}
+	_ resource.ResourceWithConfigure   = &securityGroupResource{}
+	_ resource.ResourceWithImportState = &securityGroupResource{}
+)
+
+func SecurityGroupResource() resource.Resource {
+	return &securityGroupResource{}
+}
+
+type securityGroupResource struct {
+	client *service.Proxmox
+}
+
+func (r *securityGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_security_group"
+}
+
+func (r *securityGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = securityGroupResourceSchema
+}
+
+func (r *securityGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+// returns nil when the security group no longer exists
+func (r *securityGroupResource) readSecurityGroupModel(ctx context.Context, name string) (*securityGroupModel, error) {
+	group, err := r.client.DescribeFirewallSecurityGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, nil
+	}
+
+	m := securityGroupModel{
+		ID:      types.StringValue(name),
+		Name:    types.StringValue(name),
+		Comment: fw.OptionalString(group.Comment),
+	}
+
+	rules, err := fw.ReadRules(ctx, r.client, service.SecurityGroupRulesPath(group.Group))
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		m.Rules = rules
+	}
+
+	return &m, nil
+}
+
+func (r *securityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create firewall security group method")
+	var plan securityGroupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+
+	err := r.client.CreateFirewallSecurityGroup(ctx, name, utils.OptionalToPointerString(plan.Comment.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating security group",
+			"Could not create firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = fw.ReconcileRules(ctx, r.client, service.SecurityGroupRulesPath(name), plan.Rules)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating security group",
+			"Could not add rules to firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readSecurityGroupModel(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Could not read firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Security group was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *securityGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read firewall security group method")
+	var state securityGroupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readSecurityGroupModel(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Could not read firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Security group '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *securityGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update firewall security group method")
+	var plan securityGroupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state securityGroupModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+
+	if !plan.Comment.Equal(state.Comment) {
+		err := r.client.UpdateFirewallSecurityGroupComment(ctx, name, utils.OptionalToPointerString(plan.Comment.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating security group",
+				"Could not update firewall security group comment, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	err := fw.ReconcileRules(ctx, r.client, service.SecurityGroupRulesPath(name), plan.Rules)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating security group",
+			"Could not update firewall security group rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readSecurityGroupModel(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Could not read firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Security group no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *securityGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete firewall security group method")
+	var state securityGroupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+
+	// proxmox only deletes security groups without rules
+	err := fw.ReconcileRules(ctx, r.client, service.SecurityGroupRulesPath(name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting security group",
+			"Could not remove firewall security group rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.client.DeleteFirewallSecurityGroup(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting security group",
+			"Could not delete firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *securityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	m, err := r.readSecurityGroupModel(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			"Could not read firewall security group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading security group",
+			fmt.Sprintf("Security group '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,67 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func ReadIPSetEntries(ctx context.Context, client *service.Proxmox, firewall string, name string) ([]IPSetEntryModel, error) {
+	entries, err := client.ListFirewallIPSetEntries(ctx, firewall, name)
+	if err != nil {
+		return nil, err
+	}
+
+	models := []IPSetEntryModel{}
+	for _, e := range entries {
+		models = append(models, IPSetEntryToModel(&e))
+	}
+	return models, nil
+}
+
+// makes the entries of the ip set match the planned entries, entries are identified by their cidr
+func ReconcileIPSetEntries(ctx context.Context, client *service.Proxmox, firewall string, name string, plan []IPSetEntryModel) error {
+	entries, err := client.ListFirewallIPSetEntries(ctx, firewall, name)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]IPSetEntryModel{}
+	for _, e := range entries {
+		current[e.CIDR] = IPSetEntryToModel(&e)
+	}
+
+	planned := map[string]bool{}
+	for _, m := range plan {
+		entry := m.ToEntry()
+		planned[entry.CIDR] = true
+
+		existing, ok := current[entry.CIDR]
+		switch {
+		case !ok:
+			tflog.Debug(ctx, fmt.Sprintf("Adding '%s' to ip set '%s'", entry.CIDR, name))
+			err = client.CreateFirewallIPSetEntry(ctx, firewall, name, &entry)
+		case !existing.NoMatch.Equal(m.NoMatch) || existing.Comment.ValueString() != m.Comment.ValueString():
+			tflog.Debug(ctx, fmt.Sprintf("Updating '%s' in ip set '%s'", entry.CIDR, name))
+			err = client.UpdateFirewallIPSetEntry(ctx, firewall, name, &entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for cidr := range current {
+		if planned[cidr] {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Removing '%s' from ip set '%s'", cidr, name))
+		err = client.DeleteFirewallIPSetEntry(ctx, firewall, name, cidr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package firewall
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RuleModel struct {
+	Type    types.String `tfsdk:"type"`
+	Action  types.String `tfsdk:"action"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Proto   types.String `tfsdk:"proto"`
+	Dport   types.String `tfsdk:"dport"`
+	Sport   types.String `tfsdk:"sport"`
+	Source  types.String `tfsdk:"source"`
+	Dest    types.String `tfsdk:"dest"`
+	Macro   types.String `tfsdk:"macro"`
+	Iface   types.String `tfsdk:"iface"`
+	Log     types.String `tfsdk:"log"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+type IPSetEntryModel struct {
+	CIDR    types.String `tfsdk:"cidr"`
+	NoMatch types.Bool   `tfsdk:"nomatch"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+// proxmox returns empty strings for some unset fields
+func OptionalString(s *string) types.String {
+	if s == nil || *s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*s)
+}
+
+func RuleToModel(rule *service.FirewallRule) RuleModel {
+	return RuleModel{
+		Type:    types.StringValue(rule.Type),
+		Action:  types.StringValue(rule.Action),
+		Enabled: types.BoolValue(rule.IsEnabled()),
+		Proto:   OptionalString(rule.Proto),
+		Dport:   OptionalString(rule.Dport),
+		Sport:   OptionalString(rule.Sport),
+		Source:  OptionalString(rule.Source),
+		Dest:    OptionalString(rule.Dest),
+		Macro:   OptionalString(rule.Macro),
+		Iface:   OptionalString(rule.Iface),
+		Log:     OptionalString(rule.Log),
+		Comment: OptionalString(rule.Comment),
+	}
+}
+
+func (m *RuleModel) ToRule(pos int) service.FirewallRule {
+	enable := service.BoolToInt(m.Enabled.ValueBool())
+	return service.FirewallRule{
+		Pos:     pos,
+		Type:    m.Type.ValueString(),
+		Action:  m.Action.ValueString(),
+		Enable:  &enable,
+		Proto:   utils.OptionalToPointerString(m.Proto.ValueString()),
+		Dport:   utils.OptionalToPointerString(m.Dport.ValueString()),
+		Sport:   utils.OptionalToPointerString(m.Sport.ValueString()),
+		Source:  utils.OptionalToPointerString(m.Source.ValueString()),
+		Dest:    utils.OptionalToPointerString(m.Dest.ValueString()),
+		Macro:   utils.OptionalToPointerString(m.Macro.ValueString()),
+		Iface:   utils.OptionalToPointerString(m.Iface.ValueString()),
+		Log:     utils.OptionalToPointerString(m.Log.ValueString()),
+		Comment: utils.OptionalToPointerString(m.Comment.ValueString()),
+	}
+}
+
+func IPSetEntryToModel(entry *service.FirewallIPSetEntry) IPSetEntryModel {
+	return IPSetEntryModel{
+		CIDR:    types.StringValue(entry.CIDR),
+		NoMatch: types.BoolValue(entry.NoMatch != nil && service.IntToBool(*entry.NoMatch)),
+		Comment: OptionalString(entry.Comment),
+	}
+}
+
+func (m *IPSetEntryModel) ToEntry() service.FirewallIPSetEntry {
+	nomatch := service.BoolToInt(m.NoMatch.ValueBool())
+	return service.FirewallIPSetEntry{
+		CIDR:    m.CIDR.ValueString(),
+		NoMatch: &nomatch,
+		Comment: utils.OptionalToPointerString(m.Comment.ValueString()),
+	}
+}
@@ -0,0 +1,68 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func listRules(ctx context.Context, client *service.Proxmox, rules string) ([]service.FirewallRule, error) {
+	current, err := client.ListFirewallRules(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(current, func(i, j int) bool {
+		return current[i].Pos < current[j].Pos
+	})
+	return current, nil
+}
+
+func ReadRules(ctx context.Context, client *service.Proxmox, rules string) ([]RuleModel, error) {
+	current, err := listRules(ctx, client, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	models := []RuleModel{}
+	for _, r := range current {
+		models = append(models, RuleToModel(&r))
+	}
+	return models, nil
+}
+
+// makes the rules match the planned list, rules are updated in place by position so the firewall is
+// never left without the rules that stay
+func ReconcileRules(ctx context.Context, client *service.Proxmox, rules string, plan []RuleModel) error {
+	current, err := listRules(ctx, client, rules)
+	if err != nil {
+		return err
+	}
+
+	for i, m := range plan {
+		rule := m.ToRule(i)
+		if i >= len(current) {
+			tflog.Debug(ctx, fmt.Sprintf("Creating firewall rule at position %d", i))
+			err = client.CreateFirewallRule(ctx, rules, &rule)
+		} else if !current[i].Equal(&rule) {
+			tflog.Debug(ctx, fmt.Sprintf("Updating firewall rule at position %d", i))
+			err = client.UpdateFirewallRule(ctx, rules, &rule)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// remove from the end so positions of the remaining rules do not shift
+	for pos := len(current) - 1; pos >= len(plan); pos-- {
+		tflog.Debug(ctx, fmt.Sprintf("Deleting firewall rule at position %d", pos))
+		err = client.DeleteFirewallRule(ctx, rules, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
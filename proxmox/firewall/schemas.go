@@ -0,0 +1,98 @@
+package firewall
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var Policies = []string{"ACCEPT", "REJECT", "DROP"}
+
+var LogLevels = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug", "nolog"}
+
+var NameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-_]+$`)
+
+// attributes of a single rule, rules are kept in lists as their order matters
+var RuleAttributes = map[string]rs.Attribute{
+	"type": rs.StringAttribute{
+		Required:    true,
+		Description: "The direction of the rule, or `group` to include the rules of a security group. One of `in`, `out` or `group`.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("in", "out", "group"),
+		},
+	},
+	"action": rs.StringAttribute{
+		Required:    true,
+		Description: "The action of the rule, one of `ACCEPT`, `REJECT` or `DROP`. The name of the security group for rules of type `group`.",
+	},
+	"enabled": rs.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Whether the rule is enabled.",
+		PlanModifiers: []planmodifier.Bool{
+			defaults.DefaultBool(true),
+		},
+	},
+	"proto": rs.StringAttribute{
+		Optional:    true,
+		Description: "The IP protocol, either a name from `/etc/protocols` or a protocol number.",
+	},
+	"dport": rs.StringAttribute{
+		Optional:    true,
+		Description: "The destination ports, as service names or numbers. Ranges are written as `\\d+:\\d+` and lists are separated by commas.",
+	},
+	"sport": rs.StringAttribute{
+		Optional:    true,
+		Description: "The source ports, as service names or numbers. Ranges are written as `\\d+:\\d+` and lists are separated by commas.",
+	},
+	"source": rs.StringAttribute{
+		Optional:    true,
+		Description: "The source addresses, as addresses, CIDRs, aliases or ip sets prefixed with `+`.",
+	},
+	"dest": rs.StringAttribute{
+		Optional:    true,
+		Description: "The destination addresses, as addresses, CIDRs, aliases or ip sets prefixed with `+`.",
+	},
+	"macro": rs.StringAttribute{
+		Optional:    true,
+		Description: "The name of a predefined macro, such as `SSH` or `HTTPS`.",
+	},
+	"iface": rs.StringAttribute{
+		Optional:    true,
+		Description: "The interface the rule applies to.",
+	},
+	"log": rs.StringAttribute{
+		Optional:    true,
+		Description: "The log level of the rule.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(LogLevels...),
+		},
+	},
+	"comment": rs.StringAttribute{
+		Optional:    true,
+		Description: "Notes on the rule.",
+	},
+}
+
+var IPSetEntryAttributes = map[string]rs.Attribute{
+	"cidr": rs.StringAttribute{
+		Required:    true,
+		Description: "The address, CIDR or alias of the entry.",
+	},
+	"nomatch": rs.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Whether to exclude the entry from the ip set.",
+		PlanModifiers: []planmodifier.Bool{
+			defaults.DefaultBool(false),
+		},
+	},
+	"comment": rs.StringAttribute{
+		Optional:    true,
+		Description: "Notes on the entry.",
+	},
+}
@@ -0,0 +1,223 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &groupResource{}
+	_ resource.ResourceWithConfigure   = &groupResource{}
+	_ resource.ResourceWithImportState = &groupResource{}
+)
+
+func GroupResource() resource.Resource {
+	return &groupResource{}
+}
+
+type groupResource struct {
+	client *service.Proxmox
+}
+
+func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ha_group"
+}
+
+func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = groupResourceSchema
+}
+
+func (r *groupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+// returns nil when the group no longer exists
+func (r *groupResource) readGroupModel(ctx context.Context, id string) (*groupModel, error) {
+	tflog.Debug(ctx, fmt.Sprintf("Reading HA group model: %s", id))
+	group, err := r.client.DescribeHAGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, nil
+	}
+
+	m := GroupToModel(group)
+	return &m, nil
+}
+
+func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create HA group method")
+	var plan groupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateHAGroup(ctx, &service.CreateHAGroupInput{
+		Group:      plan.Group.ValueString(),
+		Nodes:      plan.nodes(),
+		Restricted: plan.Restricted.ValueBool(),
+		NoFailback: plan.NoFailback.ValueBool(),
+		Comment:    utils.OptionalToPointerString(plan.Comment.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating HA group",
+			"Could not create HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	group, err := r.readGroupModel(ctx, plan.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"Could not read HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"HA group was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read HA group method")
+	var state groupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.readGroupModel(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"Could not read HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if group == nil {
+		tflog.Warn(ctx, fmt.Sprintf("HA group '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update HA group method")
+	var plan groupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateHAGroup(ctx, &service.UpdateHAGroupInput{
+		Group:      plan.Group.ValueString(),
+		Nodes:      plan.nodes(),
+		Restricted: plan.Restricted.ValueBool(),
+		NoFailback: plan.NoFailback.ValueBool(),
+		Comment:    utils.OptionalToPointerString(plan.Comment.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating HA group",
+			"Could not update HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	group, err := r.readGroupModel(ctx, plan.Group.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"Could not read HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"HA group no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, group)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete HA group method")
+	var state groupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteHAGroup(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting HA group",
+			"Could not delete HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	model, err := r.readGroupModel(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			"Could not read HA group, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if model == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA group",
+			fmt.Sprintf("HA group '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
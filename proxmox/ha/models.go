@@ -0,0 +1,106 @@
+package ha
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type groupNodeModel struct {
+	Node     types.String `tfsdk:"node"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+type groupModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Group      types.String     `tfsdk:"group"`
+	Nodes      []groupNodeModel `tfsdk:"nodes"`
+	Restricted types.Bool       `tfsdk:"restricted"`
+	NoFailback types.Bool       `tfsdk:"nofailback"`
+	Comment    types.String     `tfsdk:"comment"`
+}
+
+type resourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Sid         types.String `tfsdk:"sid"`
+	State       types.String `tfsdk:"state"`
+	Group       types.String `tfsdk:"group"`
+	MaxRestart  types.Int64  `tfsdk:"max_restart"`
+	MaxRelocate types.Int64  `tfsdk:"max_relocate"`
+	Comment     types.String `tfsdk:"comment"`
+}
+
+func optionalString(s *string) types.String {
+	if s == nil || *s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*s)
+}
+
+func GroupToModel(group *service.HAGroup) groupModel {
+	nodes := []groupNodeModel{}
+	for _, n := range group.Nodes {
+		node := groupNodeModel{
+			Node:     types.StringValue(n.Node),
+			Priority: types.Int64Null(),
+		}
+		if n.Priority != nil {
+			node.Priority = types.Int64Value(int64(*n.Priority))
+		}
+		nodes = append(nodes, node)
+	}
+
+	return groupModel{
+		ID:         types.StringValue(group.Group),
+		Group:      types.StringValue(group.Group),
+		Nodes:      nodes,
+		Restricted: types.BoolValue(group.Restricted),
+		NoFailback: types.BoolValue(group.NoFailback),
+		Comment:    optionalString(group.Comment),
+	}
+}
+
+func (m *groupModel) nodes() []service.HAGroupNode {
+	nodes := []service.HAGroupNode{}
+	for _, n := range m.Nodes {
+		node := service.HAGroupNode{
+			Node: n.Node.ValueString(),
+		}
+		if !n.Priority.IsNull() && !n.Priority.IsUnknown() {
+			p := int(n.Priority.ValueInt64())
+			node.Priority = &p
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func ResourceToModel(resource *service.HAResource) resourceModel {
+	m := resourceModel{
+		ID:          types.StringValue(resource.Sid),
+		Sid:         types.StringValue(resource.Sid),
+		State:       types.StringValue(string(resource.State)),
+		Group:       optionalString(resource.Group),
+		MaxRestart:  types.Int64Value(1),
+		MaxRelocate: types.Int64Value(1),
+		Comment:     optionalString(resource.Comment),
+	}
+	if resource.MaxRestart != nil {
+		m.MaxRestart = types.Int64Value(int64(*resource.MaxRestart))
+	}
+	if resource.MaxRelocate != nil {
+		m.MaxRelocate = types.Int64Value(int64(*resource.MaxRelocate))
+	}
+	return m
+}
+
+func (m *resourceModel) input() *service.HAResourceInput {
+	return &service.HAResourceInput{
+		Sid:         m.Sid.ValueString(),
+		State:       service.HAResourceState(m.State.ValueString()),
+		Group:       utils.OptionalToPointerString(m.Group.ValueString()),
+		MaxRestart:  int(m.MaxRestart.ValueInt64()),
+		MaxRelocate: int(m.MaxRelocate.ValueInt64()),
+		Comment:     utils.OptionalToPointerString(m.Comment.ValueString()),
+	}
+}
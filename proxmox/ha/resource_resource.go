@@ -0,0 +1,210 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &resourceResource{}
+	_ resource.ResourceWithConfigure   = &resourceResource{}
+	_ resource.ResourceWithImportState = &resourceResource{}
+)
+
+func ResourceResource() resource.Resource {
+	return &resourceResource{}
+}
+
+type resourceResource struct {
+	client *service.Proxmox
+}
+
+func (r *resourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ha_resource"
+}
+
+func (r *resourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceResourceSchema
+}
+
+func (r *resourceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+// returns nil when the resource is no longer part of the ha configuration
+func (r *resourceResource) readResourceModel(ctx context.Context, id string) (*resourceModel, error) {
+	tflog.Debug(ctx, fmt.Sprintf("Reading HA resource model: %s", id))
+	haResource, err := r.client.DescribeHAResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if haResource == nil {
+		return nil, nil
+	}
+
+	m := ResourceToModel(haResource)
+	return &m, nil
+}
+
+func (r *resourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create HA resource method")
+	var plan resourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateHAResource(ctx, plan.input())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating HA resource",
+			"Could not create HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	haResource, err := r.readResourceModel(ctx, plan.Sid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"Could not read HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if haResource == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"HA resource was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, haResource)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read HA resource method")
+	var state resourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	haResource, err := r.readResourceModel(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"Could not read HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if haResource == nil {
+		tflog.Warn(ctx, fmt.Sprintf("HA resource '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, haResource)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update HA resource method")
+	var plan resourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateHAResource(ctx, plan.input())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating HA resource",
+			"Could not update HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	haResource, err := r.readResourceModel(ctx, plan.Sid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"Could not read HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if haResource == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"HA resource no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, haResource)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete HA resource method")
+	var state resourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteHAResource(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting HA resource",
+			"Could not delete HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *resourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	model, err := r.readResourceModel(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			"Could not read HA resource, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if model == nil {
+		resp.Diagnostics.AddError(
+			"Error reading HA resource",
+			fmt.Sprintf("HA resource '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,141 @@
+package ha
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var groupResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the HA group, the same as its name.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"group": rs.StringAttribute{
+			Required:    true,
+			Description: "The name of the HA group.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]+$`), "group must start with a letter and only contain letters, numbers, `-` or `_`"),
+			},
+		},
+		"nodes": rs.SetNestedAttribute{
+			Required:    true,
+			Description: "The nodes resources in the group may run on.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: map[string]rs.Attribute{
+					"node": rs.StringAttribute{
+						Required:    true,
+						Description: "The name of the node.",
+					},
+					"priority": rs.Int64Attribute{
+						Optional:    true,
+						Description: "The priority of the node, resources run on the available nodes with the highest priority.",
+						Validators: []validator.Int64{
+							int64validator.Between(0, 1000),
+						},
+					},
+				},
+			},
+		},
+		"restricted": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether resources in the group may only run on the nodes of the group. Resources are stopped when none of them are available.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+		"nofailback": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to keep resources where they are when a node with a higher priority comes back online.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the HA group.",
+		},
+	},
+}
+
+var resourceResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the HA resource, the same as its sid.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"sid": rs.StringAttribute{
+			Required:    true,
+			Description: "The resource to manage. Formatted as `vm:{vm_id}` for virtual machines or `ct:{vm_id}` for containers.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(vm|ct):[0-9]+$`), "sid must be formatted as `vm:{vm_id}` or `ct:{vm_id}`"),
+			},
+		},
+		"state": rs.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The state the HA manager keeps the resource in. One of `started`, `stopped`, `disabled` or `ignored`.",
+			PlanModifiers: []planmodifier.String{
+				defaults.DefaultString("started"),
+			},
+			Validators: []validator.String{
+				stringvalidator.OneOf("started", "stopped", "disabled", "ignored"),
+			},
+		},
+		"group": rs.StringAttribute{
+			Optional:    true,
+			Description: "The HA group the resource is part of.",
+		},
+		"max_restart": rs.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The maximum number of times the resource is restarted on its node after failing to start.",
+			PlanModifiers: []planmodifier.Int64{
+				defaults.DefaultInt64(1),
+			},
+			Validators: []validator.Int64{
+				int64validator.Between(0, 10),
+			},
+		},
+		"max_relocate": rs.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The maximum number of times the resource is relocated to another node after failing to start.",
+			PlanModifiers: []planmodifier.Int64{
+				defaults.DefaultInt64(1),
+			},
+			Validators: []validator.Int64{
+				int64validator.Between(0, 10),
+			},
+		},
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the HA resource.",
+		},
+	},
+}
@@ -0,0 +1,145 @@
+package node_firewall
+
+import (
+	"strings"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/network"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	optionsName = "options"
+	rulesName   = "rules"
+)
+
+type optionsModel struct {
+	ID                               types.String `tfsdk:"id"`
+	Node                             types.String `tfsdk:"node"`
+	Enabled                          types.Bool   `tfsdk:"enabled"`
+	Ndp                              types.Bool   `tfsdk:"ndp"`
+	LogLevelIn                       types.String `tfsdk:"log_level_in"`
+	LogLevelOut                      types.String `tfsdk:"log_level_out"`
+	LogNfConntrack                   types.Bool   `tfsdk:"log_nf_conntrack"`
+	NfConntrackAllowInvalid          types.Bool   `tfsdk:"nf_conntrack_allow_invalid"`
+	NfConntrackHelpers               types.Set    `tfsdk:"nf_conntrack_helpers"`
+	NfConntrackMax                   types.Int64  `tfsdk:"nf_conntrack_max"`
+	NfConntrackTcpTimeoutEstablished types.Int64  `tfsdk:"nf_conntrack_tcp_timeout_established"`
+	NfConntrackTcpTimeoutSynRecv     types.Int64  `tfsdk:"nf_conntrack_tcp_timeout_syn_recv"`
+	NoSmurfs                         types.Bool   `tfsdk:"no_smurfs"`
+	SmurfLogLevel                    types.String `tfsdk:"smurf_log_level"`
+	TcpFlags                         types.Bool   `tfsdk:"tcp_flags"`
+	TcpFlagsLogLevel                 types.String `tfsdk:"tcp_flags_log_level"`
+}
+
+type rulesModel struct {
+	ID    types.String   `tfsdk:"id"`
+	Node  types.String   `tfsdk:"node"`
+	Rules []fw.RuleModel `tfsdk:"rules"`
+}
+
+func boolOption(i *int, d bool) types.Bool {
+	if i == nil {
+		return types.BoolValue(d)
+	}
+	return types.BoolValue(service.IntToBool(*i))
+}
+
+func stringOption(s *string, d string) types.String {
+	if s == nil {
+		return types.StringValue(d)
+	}
+	return types.StringValue(*s)
+}
+
+func intOption(i *int) types.Int64 {
+	if i == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*i))
+}
+
+// options proxmox does not return are at their defaults, the conntrack limits and helpers stay null
+// unless they are set
+func OptionsToModel(node string, o *service.NodeFirewallOptions) optionsModel {
+	m := optionsModel{
+		ID:                               types.StringValue(network.FormId(node, optionsName)),
+		Node:                             types.StringValue(node),
+		Enabled:                          boolOption(o.Enable, true),
+		Ndp:                              boolOption(o.Ndp, true),
+		LogLevelIn:                       stringOption(o.LogLevelIn, "nolog"),
+		LogLevelOut:                      stringOption(o.LogLevelOut, "nolog"),
+		LogNfConntrack:                   boolOption(o.LogNfConntrack, false),
+		NfConntrackAllowInvalid:          boolOption(o.NfConntrackAllowInvalid, false),
+		NfConntrackHelpers:               types.SetNull(types.StringType),
+		NfConntrackMax:                   intOption(o.NfConntrackMax),
+		NfConntrackTcpTimeoutEstablished: intOption(o.NfConntrackTcpTimeoutEstablished),
+		NfConntrackTcpTimeoutSynRecv:     intOption(o.NfConntrackTcpTimeoutSynRecv),
+		NoSmurfs:                         boolOption(o.NoSmurfs, true),
+		SmurfLogLevel:                    stringOption(o.SmurfLogLevel, "nolog"),
+		TcpFlags:                         boolOption(o.TcpFlags, false),
+		TcpFlagsLogLevel:                 stringOption(o.TcpFlagsLogLevel, "nolog"),
+	}
+	if o.NfConntrackHelpers != nil && *o.NfConntrackHelpers != "" {
+		m.NfConntrackHelpers = utils.UnpackSetType(strings.Split(*o.NfConntrackHelpers, ","))
+	}
+	return m
+}
+
+// returns the options to set and the unset options to reset to their defaults
+func (m *optionsModel) options() (map[string]interface{}, []string) {
+	options := map[string]interface{}{
+		"enable":                     service.BoolToInt(m.Enabled.ValueBool()),
+		"ndp":                        service.BoolToInt(m.Ndp.ValueBool()),
+		"log_level_in":               m.LogLevelIn.ValueString(),
+		"log_level_out":              m.LogLevelOut.ValueString(),
+		"log_nf_conntrack":           service.BoolToInt(m.LogNfConntrack.ValueBool()),
+		"nf_conntrack_allow_invalid": service.BoolToInt(m.NfConntrackAllowInvalid.ValueBool()),
+		"nosmurfs":                   service.BoolToInt(m.NoSmurfs.ValueBool()),
+		"smurf_log_level":            m.SmurfLogLevel.ValueString(),
+		"tcpflags":                   service.BoolToInt(m.TcpFlags.ValueBool()),
+		"tcp_flags_log_level":        m.TcpFlagsLogLevel.ValueString(),
+	}
+	deletes := []string{}
+
+	if m.NfConntrackHelpers.IsNull() {
+		deletes = append(deletes, "nf_conntrack_helpers")
+	} else {
+		options["nf_conntrack_helpers"] = strings.Join(utils.SetTypeToStringSlice(m.NfConntrackHelpers), ",")
+	}
+
+	limits := map[string]types.Int64{
+		"nf_conntrack_max":                     m.NfConntrackMax,
+		"nf_conntrack_tcp_timeout_established": m.NfConntrackTcpTimeoutEstablished,
+		"nf_conntrack_tcp_timeout_syn_recv":    m.NfConntrackTcpTimeoutSynRecv,
+	}
+	for k, v := range limits {
+		if v.IsNull() {
+			deletes = append(deletes, k)
+		} else {
+			options[k] = v.ValueInt64()
+		}
+	}
+
+	return options, deletes
+}
+
+// options reset when the resource is deleted
+var optionKeys = []string{
+	"enable",
+	"ndp",
+	"log_level_in",
+	"log_level_out",
+	"log_nf_conntrack",
+	"nf_conntrack_allow_invalid",
+	"nf_conntrack_helpers",
+	"nf_conntrack_max",
+	"nf_conntrack_tcp_timeout_established",
+	"nf_conntrack_tcp_timeout_syn_recv",
+	"nosmurfs",
+	"smurf_log_level",
+	"tcpflags",
+	"tcp_flags_log_level",
+}
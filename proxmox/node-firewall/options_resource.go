@@ -0,0 +1,181 @@
+package node_firewall
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/network"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &optionsResource{}
+	_ resource.ResourceWithConfigure   = &optionsResource{}
+	_ resource.ResourceWithImportState = &optionsResource{}
+)
+
+func OptionsResource() resource.Resource {
+	return &optionsResource{}
+}
+
+type optionsResource struct {
+	client *service.Proxmox
+}
+
+func (r *optionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_firewall_options"
+}
+
+func (r *optionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = optionsResourceSchema
+}
+
+func (r *optionsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *optionsResource) readOptionsModel(ctx context.Context, node string) (*optionsModel, error) {
+	options, err := r.client.GetNodeFirewallOptions(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	m := OptionsToModel(node, options)
+	return &m, nil
+}
+
+func (r *optionsResource) apply(ctx context.Context, plan *optionsModel) (*optionsModel, error) {
+	node := plan.Node.ValueString()
+
+	options, deletes := plan.options()
+	err := r.client.UpdateFirewallOptions(ctx, service.NodeFirewallPath(node), options, deletes)
+	if err != nil {
+		return nil, err
+	}
+	return r.readOptionsModel(ctx, node)
+}
+
+func (r *optionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create node firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure node firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read node firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read node firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update node firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure node firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// resets the options to their defaults
+func (r *optionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete node firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateFirewallOptions(ctx, service.NodeFirewallPath(state.Node.ValueString()), nil, optionKeys)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resetting firewall options",
+			"Could not reset node firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *optionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, name, err := network.UnpackId(req.ID)
+	if err != nil || name != optionsName {
+		resp.Diagnostics.AddError(
+			"Error importing firewall options",
+			"Expected import identifier with format {node}/options, got: "+req.ID,
+		)
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, node)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read node firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
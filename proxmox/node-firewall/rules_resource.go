@@ -0,0 +1,186 @@
+package node_firewall
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/network"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &rulesResource{}
+	_ resource.ResourceWithConfigure   = &rulesResource{}
+	_ resource.ResourceWithImportState = &rulesResource{}
+)
+
+func RulesResource() resource.Resource {
+	return &rulesResource{}
+}
+
+type rulesResource struct {
+	client *service.Proxmox
+}
+
+func (r *rulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_firewall_rules"
+}
+
+func (r *rulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rulesResourceSchema
+}
+
+func (r *rulesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *rulesResource) readRulesModel(ctx context.Context, node string) (*rulesModel, error) {
+	rules, err := fw.ReadRules(ctx, r.client, service.FirewallRulesPath(service.NodeFirewallPath(node)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &rulesModel{
+		ID:    types.StringValue(network.FormId(node, rulesName)),
+		Node:  types.StringValue(node),
+		Rules: rules,
+	}, nil
+}
+
+func (r *rulesResource) apply(ctx context.Context, plan *rulesModel) (*rulesModel, error) {
+	node := plan.Node.ValueString()
+
+	err := fw.ReconcileRules(ctx, r.client, service.FirewallRulesPath(service.NodeFirewallPath(node)), plan.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return r.readRulesModel(ctx, node)
+}
+
+func (r *rulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create node firewall rules method")
+	var plan rulesModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall rules",
+			"Could not configure node firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read node firewall rules method")
+	var state rulesModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readRulesModel(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall rules",
+			"Could not read node firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update node firewall rules method")
+	var plan rulesModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall rules",
+			"Could not configure node firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete node firewall rules method")
+	var state rulesModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := service.FirewallRulesPath(service.NodeFirewallPath(state.Node.ValueString()))
+	err := fw.ReconcileRules(ctx, r.client, rules, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting firewall rules",
+			"Could not delete node firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *rulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, name, err := network.UnpackId(req.ID)
+	if err != nil || name != rulesName {
+		resp.Diagnostics.AddError(
+			"Error importing firewall rules",
+			"Expected import identifier with format {node}/rules, got: "+req.ID,
+		)
+		return
+	}
+
+	m, err := r.readRulesModel(ctx, node)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall rules",
+			"Could not read node firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
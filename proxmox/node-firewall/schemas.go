@@ -0,0 +1,111 @@
+package node_firewall
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var conntrackHelpers = []string{"amanda", "ftp", "irc", "netbios-ns", "pptp", "sane", "sip", "snmp", "tftp"}
+
+var nodeAttribute = rs.StringAttribute{
+	Required:    true,
+	Description: "The node the firewall belongs to.",
+	PlanModifiers: []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	},
+}
+
+func idAttribute(description string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func boolOptionAttribute(description string, d bool) rs.BoolAttribute {
+	return rs.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.Bool{
+			defaults.DefaultBool(d),
+		},
+	}
+}
+
+func logLevelAttribute(description string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			defaults.DefaultString("nolog"),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf(fw.LogLevels...),
+		},
+	}
+}
+
+func limitAttribute(description string, min int64) rs.Int64Attribute {
+	return rs.Int64Attribute{
+		Optional:    true,
+		Description: description,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(min),
+		},
+	}
+}
+
+var optionsResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":                         idAttribute("The id of the firewall options. Formatted as `{node}/options`."),
+		"node":                       nodeAttribute,
+		"enabled":                    boolOptionAttribute("Whether the firewall of the node is enabled. The cluster firewall must also be enabled.", true),
+		"ndp":                        boolOptionAttribute("Whether to allow the IPv6 neighbor discovery protocol.", true),
+		"log_level_in":               logLevelAttribute("The log level for incoming traffic."),
+		"log_level_out":              logLevelAttribute("The log level for outgoing traffic."),
+		"log_nf_conntrack":           boolOptionAttribute("Whether to log connection tracking information.", false),
+		"nf_conntrack_allow_invalid": boolOptionAttribute("Whether to allow packets that connection tracking considers invalid.", false),
+		"nf_conntrack_helpers": rs.SetAttribute{
+			Optional:    true,
+			Description: "The connection tracking helpers to enable for protocols that open related connections.",
+			ElementType: types.StringType,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(stringvalidator.OneOf(conntrackHelpers...)),
+			},
+		},
+		"nf_conntrack_max":                     limitAttribute("The maximum number of tracked connections. Defaults to the proxmox default when unset.", 32768),
+		"nf_conntrack_tcp_timeout_established": limitAttribute("The seconds an established connection is tracked without traffic. Defaults to the proxmox default when unset.", 7875),
+		"nf_conntrack_tcp_timeout_syn_recv":    limitAttribute("The seconds a connection is tracked while waiting for the final ACK of the handshake. Defaults to the proxmox default when unset.", 30),
+		"no_smurfs":                            boolOptionAttribute("Whether to enable the SMURF filter, which drops broadcast sourced packets.", true),
+		"smurf_log_level":                      logLevelAttribute("The log level for packets dropped by the SMURF filter."),
+		"tcp_flags":                            boolOptionAttribute("Whether to drop packets with illegal combinations of TCP flags.", false),
+		"tcp_flags_log_level":                  logLevelAttribute("The log level for packets dropped by the TCP flag filter."),
+	},
+}
+
+var rulesResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":   idAttribute("The id of the firewall rules. Formatted as `{node}/rules`."),
+		"node": nodeAttribute,
+		"rules": rs.ListNestedAttribute{
+			Required:    true,
+			Description: "The firewall rules of the node, in the order they are evaluated. The resource manages every rule of the node.",
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: fw.RuleAttributes,
+			},
+		},
+	},
+}
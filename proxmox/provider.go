@@ -5,16 +5,23 @@ import (
 	"os"
 
 	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	cluster_firewall "github.com/awlsring/terraform-provider-proxmox/proxmox/cluster-firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/ha"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/local-storage/lvm"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/local-storage/lvmthin"
 	zfs_pool "github.com/awlsring/terraform-provider-proxmox/proxmox/local-storage/zfs"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/network/bonds"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/network/bridges"
+	node_firewall "github.com/awlsring/terraform-provider-proxmox/proxmox/node-firewall"
 	lvm_node "github.com/awlsring/terraform-provider-proxmox/proxmox/node-storage/lvm"
 	lvmthin_node "github.com/awlsring/terraform-provider-proxmox/proxmox/node-storage/lvmthin"
 	nfs_node "github.com/awlsring/terraform-provider-proxmox/proxmox/node-storage/nfs"
 	zfs_node "github.com/awlsring/terraform-provider-proxmox/proxmox/node-storage/zfs"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/backups"
+	vm_firewall "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/guest"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/snapshots"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/templates"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms"
 	resource_pools "github.com/awlsring/terraform-provider-proxmox/proxmox/resource-pools"
@@ -40,11 +47,22 @@ var (
 type ProxmoxProvider struct{}
 
 type ProxmoxProviderConfig struct {
-	User     types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	ApiKey   types.String `tfsdk:"api_key"`
-	Endpoint types.String `tfsdk:"endpoint"`
-	Insecure types.Bool   `tfsdk:"insecure"`
+	User     types.String              `tfsdk:"username"`
+	Password types.String              `tfsdk:"password"`
+	ApiKey   types.String              `tfsdk:"api_key"`
+	Endpoint types.String              `tfsdk:"endpoint"`
+	Insecure types.Bool                `tfsdk:"insecure"`
+	SSH      *ProxmoxProviderSSHConfig `tfsdk:"ssh"`
+}
+
+type ProxmoxProviderSSHConfig struct {
+	User           types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	Port           types.Int64  `tfsdk:"port"`
+	KnownHostsFile types.String `tfsdk:"known_hosts_file"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	NodeAddresses  types.Map    `tfsdk:"node_addresses"`
 }
 
 func New() provider.Provider {
@@ -102,6 +120,43 @@ func (p *ProxmoxProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:    true,
 				Description: "Skip TLS verification. Defaults to true.",
 			},
+			"ssh": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "SSH access to the nodes, used for operations the api does not expose such as uploading cloud-init snippets.",
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						Optional:    true,
+						Description: "The user to connect as. Defaults to root.",
+					},
+					"password": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The password of the ssh user. Can also be set with PROXMOX_SSH_PASSWORD.",
+					},
+					"private_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The private key of the ssh user. Can also be set with PROXMOX_SSH_PRIVATE_KEY.",
+					},
+					"port": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The ssh port of the nodes. Defaults to 22.",
+					},
+					"known_hosts_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "A known hosts file to verify node host keys against. Required unless `insecure` is set.",
+					},
+					"insecure": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Skip verifying node host keys. Defaults to false.",
+					},
+					"node_addresses": schema.MapAttribute{
+						Optional:    true,
+						Description: "Addresses to reach nodes at, keyed by node name. Defaults to the node address reported by the cluster.",
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -163,6 +218,19 @@ func (p *ProxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 		SkipVerify: insecure,
 	}
 
+	if cfg.SSH != nil {
+		scfg.SSH = formSSHConfig(cfg.SSH)
+		if scfg.SSH.KnownHostsFile == "" && !scfg.SSH.Insecure {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh").AtName("known_hosts_file"),
+				"Proxmox ssh host key verification",
+				"The provider cannot verify the host keys of the nodes as there is no known hosts file. "+
+					"Set known_hosts_file to verify them, or set insecure to skip the verification.",
+			)
+			return
+		}
+	}
+
 	ctx = tflog.SetField(ctx, "proxmox_endpoint", endpoint)
 	ctx = tflog.SetField(ctx, "proxmox_username", user)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "proxmox_password")
@@ -186,6 +254,48 @@ func (p *ProxmoxProvider) Configure(ctx context.Context, req provider.ConfigureR
 	tflog.Debug(ctx, "Configured Proxmox client", map[string]any{"success": true})
 }
 
+func formSSHConfig(cfg *ProxmoxProviderSSHConfig) *service.SSHConfig {
+	c := service.SSHConfig{
+		Username:      "root",
+		Password:      os.Getenv("PROXMOX_SSH_PASSWORD"),
+		PrivateKey:    os.Getenv("PROXMOX_SSH_PRIVATE_KEY"),
+		Port:          22,
+		NodeAddresses: map[string]string{},
+	}
+
+	if !cfg.User.IsNull() {
+		c.Username = cfg.User.ValueString()
+	}
+
+	if !cfg.Password.IsNull() {
+		c.Password = cfg.Password.ValueString()
+	}
+
+	if !cfg.PrivateKey.IsNull() {
+		c.PrivateKey = cfg.PrivateKey.ValueString()
+	}
+
+	if !cfg.Port.IsNull() {
+		c.Port = int(cfg.Port.ValueInt64())
+	}
+
+	if !cfg.KnownHostsFile.IsNull() {
+		c.KnownHostsFile = cfg.KnownHostsFile.ValueString()
+	}
+
+	if !cfg.Insecure.IsNull() {
+		c.Insecure = cfg.Insecure.ValueBool()
+	}
+
+	for node, address := range cfg.NodeAddresses.Elements() {
+		if a, ok := address.(types.String); ok {
+			c.NodeAddresses[node] = a.ValueString()
+		}
+	}
+
+	return &c
+}
+
 func (p *ProxmoxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resource_pools.Resource,
@@ -199,6 +309,22 @@ func (p *ProxmoxProvider) Resources(ctx context.Context) []func() resource.Resou
 		lvm_storage_class.Resource,
 		lvmthin_storage_class.Resource,
 		vms.Resource,
+		snapshots.Resource,
+		backups.Resource,
+		guest.ExecResource,
+		guest.FileResource,
+		ha.GroupResource,
+		ha.ResourceResource,
+		vm_firewall.OptionsResource,
+		vm_firewall.RulesResource,
+		vm_firewall.IPSetResource,
+		vm_firewall.AliasResource,
+		cluster_firewall.OptionsResource,
+		cluster_firewall.SecurityGroupResource,
+		cluster_firewall.IPSetResource,
+		cluster_firewall.AliasResource,
+		node_firewall.OptionsResource,
+		node_firewall.RulesResource,
 	}
 }
 
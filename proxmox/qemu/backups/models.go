@@ -0,0 +1,57 @@
+package backups
+
+import (
+	"strings"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type backupTimeoutsModel struct {
+	Create types.Int64 `tfsdk:"create"`
+	Delete types.Int64 `tfsdk:"delete"`
+}
+
+type backupModel struct {
+	ID              types.String         `tfsdk:"id"`
+	Node            types.String         `tfsdk:"node"`
+	VmId            types.Int64          `tfsdk:"vm_id"`
+	Storage         types.String         `tfsdk:"storage"`
+	Mode            types.String         `tfsdk:"mode"`
+	Compression     types.String         `tfsdk:"compression"`
+	Notes           types.String         `tfsdk:"notes"`
+	DeleteOnDestroy types.Bool           `tfsdk:"delete_on_destroy"`
+	VolumeId        types.String         `tfsdk:"volume_id"`
+	Format          types.String         `tfsdk:"format"`
+	Size            types.Int64          `tfsdk:"size"`
+	CreatedAt       types.Int64          `tfsdk:"created_at"`
+	Timeouts        *backupTimeoutsModel `tfsdk:"timeouts"`
+}
+
+func BackupToModel(backup *service.BackupVolume, state *backupModel) backupModel {
+	m := backupModel{
+		ID:              types.StringValue(backup.VolumeId),
+		Node:            state.Node,
+		VmId:            state.VmId,
+		Storage:         state.Storage,
+		Mode:            state.Mode,
+		Compression:     state.Compression,
+		DeleteOnDestroy: state.DeleteOnDestroy,
+		Timeouts:        state.Timeouts,
+		VolumeId:        types.StringValue(backup.VolumeId),
+		Format:          types.StringValue(backup.Format),
+		Size:            types.Int64Value(backup.Size),
+		CreatedAt:       types.Int64Value(backup.Created),
+	}
+
+	if backup.Notes != "" {
+		m.Notes = types.StringValue(backup.Notes)
+	}
+
+	// templated notes are rendered by proxmox when the backup is taken
+	if strings.Contains(state.Notes.ValueString(), "{{") {
+		m.Notes = state.Notes
+	}
+
+	return m
+}
@@ -0,0 +1,295 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &backupResource{}
+	_ resource.ResourceWithConfigure = &backupResource{}
+)
+
+const defaultTimeout = 3600
+
+func Resource() resource.Resource {
+	return &backupResource{}
+}
+
+type backupResource struct {
+	client *service.Proxmox
+}
+
+func (r *backupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_backup"
+}
+
+func (r *backupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema
+}
+
+func (r *backupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (m *backupModel) createTimeout() int64 {
+	if m.Timeouts == nil {
+		return defaultTimeout
+	}
+	return utils.LoadTimeout(m.Timeouts.Create, defaultTimeout)
+}
+
+func (m *backupModel) deleteTimeout() int64 {
+	if m.Timeouts == nil {
+		return defaultTimeout
+	}
+	return utils.LoadTimeout(m.Timeouts.Delete, defaultTimeout)
+}
+
+func (r *backupResource) listVolumeIds(ctx context.Context, node string, storage string, vmId int) ([]string, error) {
+	backups, err := r.client.ListVirtualMachineBackups(ctx, node, storage, vmId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, b := range backups {
+		ids = append(ids, b.VolumeId)
+	}
+	return ids, nil
+}
+
+// vzdump does not return the archive it wrote, so it is found by comparing the storage before and after
+func (r *backupResource) findCreatedBackup(ctx context.Context, node string, storage string, vmId int, existing []string) (*service.BackupVolume, error) {
+	backups, err := r.client.ListVirtualMachineBackups(ctx, node, storage, vmId)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *service.BackupVolume
+	for i, b := range backups {
+		if utils.ListContains(existing, b.VolumeId) {
+			continue
+		}
+		if created == nil || b.Created > created.Created {
+			created = &backups[i]
+		}
+	}
+
+	if created == nil {
+		return nil, fmt.Errorf("backup task finished but no new archive was found on storage %s", storage)
+	}
+	return created, nil
+}
+
+func (r *backupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create backup method")
+	var plan backupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := plan.Node.ValueString()
+	storage := plan.Storage.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+
+	existing, err := r.listVolumeIds(ctx, node, storage, vmId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating backup",
+			"Could not list existing backups, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	upid, err := r.client.BackupVirtualMachine(ctx, &service.BackupVirtualMachineInput{
+		Node:        node,
+		VmId:        vmId,
+		Storage:     storage,
+		Mode:        plan.Mode.ValueString(),
+		Compression: plan.Compression.ValueString(),
+		Notes:       utils.OptionalToPointerString(plan.Notes.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating backup",
+			"Could not create backup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "waiting for backup task "+upid)
+	err = r.client.WaitForTask(ctx, node, upid, plan.createTimeout())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating backup",
+			"Backup task did not complete: "+err.Error(),
+		)
+		return
+	}
+
+	backup, err := r.findCreatedBackup(ctx, node, storage, vmId, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading backup",
+			"Could not read backup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	model := BackupToModel(backup, &plan)
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// returns nil when the archive no longer exists
+func (r *backupResource) readBackupModel(ctx context.Context, state *backupModel) (*backupModel, error) {
+	tflog.Debug(ctx, fmt.Sprintf("Reading backup model: %s", state.VolumeId.ValueString()))
+
+	backups, err := r.client.ListVirtualMachineBackups(ctx, state.Node.ValueString(), state.Storage.ValueString(), int(state.VmId.ValueInt64()))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range backups {
+		if b.VolumeId == state.VolumeId.ValueString() {
+			m := BackupToModel(&b, state)
+			return &m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *backupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read backup method")
+	var state backupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backup, err := r.readBackupModel(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading backup",
+			"Could not read backup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if backup == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Backup '%s' no longer exists, removing from state", state.VolumeId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, backup)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *backupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update backup method")
+	var plan backupModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state backupModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Notes.Equal(state.Notes) {
+		tflog.Debug(ctx, "Updating backup notes")
+		err := r.client.UpdateBackupNotes(ctx, state.Node.ValueString(), state.Storage.ValueString(), state.VolumeId.ValueString(), plan.Notes.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating backup",
+				"Could not update backup notes, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.VolumeId = state.VolumeId
+	backup, err := r.readBackupModel(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading backup",
+			"Could not read backup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if backup == nil {
+		resp.Diagnostics.AddError(
+			"Error reading backup",
+			"Backup no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, backup)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *backupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete backup method")
+	var state backupModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeleteOnDestroy.ValueBool() {
+		tflog.Debug(ctx, fmt.Sprintf("Keeping backup archive '%s'", state.VolumeId.ValueString()))
+		return
+	}
+
+	node := state.Node.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting backup archive '%s'", state.VolumeId.ValueString()))
+	upid, err := r.client.DeleteBackup(ctx, node, state.Storage.ValueString(), state.VolumeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting backup",
+			"Could not delete backup, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if upid != nil {
+		err = r.client.WaitForTask(ctx, node, *upid, state.deleteTimeout())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting backup",
+				"Could not delete backup, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
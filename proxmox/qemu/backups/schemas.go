@@ -0,0 +1,135 @@
+package backups
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var resourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the backup. Same as the volume id.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"node": rs.StringAttribute{
+			Required:    true,
+			Description: "The node the virtual machine is on.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"vm_id": rs.Int64Attribute{
+			Required:    true,
+			Description: "The identifier of the virtual machine to back up.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+			Validators: []validator.Int64{
+				int64validator.AtLeast(100),
+				int64validator.AtMost(999999999),
+			},
+		},
+		"storage": rs.StringAttribute{
+			Required:    true,
+			Description: "The storage to write the backup to. The storage must allow `backup` content.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"mode": rs.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The backup mode. `snapshot` backs up the running virtual machine, `suspend` pauses it and `stop` shuts it down for the duration of the backup.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"snapshot",
+					"suspend",
+					"stop",
+				),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+				defaults.DefaultString("snapshot"),
+			},
+		},
+		"compression": rs.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The compression of the archive.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"none",
+					"lzo",
+					"gzip",
+					"zstd",
+				),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+				defaults.DefaultString("zstd"),
+			},
+		},
+		"notes": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes to attach to the backup. Supports the `{{guestname}}`, `{{node}}`, `{{vmid}}` and `{{cluster}}` template variables when the backup is created.",
+		},
+		"delete_on_destroy": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to delete the archive from the storage when the resource is destroyed.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+		"volume_id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The volume id of the backup archive.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"format": rs.StringAttribute{
+			Computed:    true,
+			Description: "The format of the backup archive.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"size": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The size of the backup archive in bytes.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"created_at": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The time the backup was created, as a unix timestamp.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"timeouts": rs.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]rs.Attribute{
+				"create": rs.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for creating the backup.",
+				},
+				"delete": rs.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for deleting the backup.",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,287 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ipSetResource{}
+	_ resource.ResourceWithConfigure   = &ipSetResource{}
+	_ resource.ResourceWithImportState = &ipSetResource{}
+)
+
+func IPSetResource() resource.Resource {
+	return &ipSetResource{}
+}
+
+type ipSetResource struct {
+	client *service.Proxmox
+}
+
+func (r *ipSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_firewall_ipset"
+}
+
+func (r *ipSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = ipSetResourceSchema
+}
+
+func (r *ipSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+// returns nil when the ip set no longer exists
+func (r *ipSetResource) readIPSetModel(ctx context.Context, node string, vmId int, name string) (*ipSetModel, error) {
+	firewall := service.VirtualMachineFirewallPath(node, vmId)
+	ipSet, err := r.client.DescribeFirewallIPSet(ctx, firewall, name)
+	if err != nil {
+		return nil, err
+	}
+	if ipSet == nil {
+		return nil, nil
+	}
+
+	m := ipSetModel{
+		ID:      types.StringValue(formNamedId(node, vmId, name)),
+		Node:    types.StringValue(node),
+		VmId:    types.Int64Value(int64(vmId)),
+		Name:    types.StringValue(name),
+		Comment: fw.OptionalString(ipSet.Comment),
+	}
+
+	entries, err := fw.ReadIPSetEntries(ctx, r.client, firewall, ipSet.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		m.Entries = entries
+	}
+
+	return &m, nil
+}
+
+func (r *ipSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create virtual machine firewall ip set method")
+	var plan ipSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+	name := plan.Name.ValueString()
+	firewall := service.VirtualMachineFirewallPath(node, vmId)
+
+	err := r.client.CreateFirewallIPSet(ctx, firewall, name, utils.OptionalToPointerString(plan.Comment.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ip set",
+			"Could not create virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = fw.ReconcileIPSetEntries(ctx, r.client, firewall, name, plan.Entries)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ip set",
+			"Could not add entries to virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readIPSetModel(ctx, node, vmId, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"Could not read virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"IP set was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ipSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read virtual machine firewall ip set method")
+	var state ipSetModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readIPSetModel(ctx, state.Node.ValueString(), int(state.VmId.ValueInt64()), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"Could not read virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		tflog.Warn(ctx, fmt.Sprintf("IP set '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ipSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update virtual machine firewall ip set method")
+	var plan ipSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ipSetModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+	name := plan.Name.ValueString()
+	firewall := service.VirtualMachineFirewallPath(node, vmId)
+
+	if !plan.Comment.Equal(state.Comment) {
+		err := r.client.UpdateFirewallIPSetComment(ctx, firewall, name, utils.OptionalToPointerString(plan.Comment.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating ip set",
+				"Could not update virtual machine firewall ip set comment, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	err := fw.ReconcileIPSetEntries(ctx, r.client, firewall, name, plan.Entries)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ip set",
+			"Could not update virtual machine firewall ip set entries, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readIPSetModel(ctx, node, vmId, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"Could not read virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"IP set no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ipSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete virtual machine firewall ip set method")
+	var state ipSetModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := state.Name.ValueString()
+	firewall := service.VirtualMachineFirewallPath(state.Node.ValueString(), int(state.VmId.ValueInt64()))
+
+	// proxmox only deletes empty ip sets
+	err := fw.ReconcileIPSetEntries(ctx, r.client, firewall, name, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ip set",
+			"Could not remove virtual machine firewall ip set entries, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.client.DeleteFirewallIPSet(ctx, firewall, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ip set",
+			"Could not delete virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ipSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, vmId, name, err := unpackNamedId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing ip set",
+			err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readIPSetModel(ctx, node, vmId, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			"Could not read virtual machine firewall ip set, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if m == nil {
+		resp.Diagnostics.AddError(
+			"Error reading ip set",
+			fmt.Sprintf("IP set '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
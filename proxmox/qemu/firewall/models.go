@@ -0,0 +1,144 @@
+package firewall
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type optionsModel struct {
+	ID          types.String `tfsdk:"id"`
+	Node        types.String `tfsdk:"node"`
+	VmId        types.Int64  `tfsdk:"vm_id"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	PolicyIn    types.String `tfsdk:"policy_in"`
+	PolicyOut   types.String `tfsdk:"policy_out"`
+	Dhcp        types.Bool   `tfsdk:"dhcp"`
+	Ndp         types.Bool   `tfsdk:"ndp"`
+	Radv        types.Bool   `tfsdk:"radv"`
+	IpFilter    types.Bool   `tfsdk:"ipfilter"`
+	MacFilter   types.Bool   `tfsdk:"macfilter"`
+	LogLevelIn  types.String `tfsdk:"log_level_in"`
+	LogLevelOut types.String `tfsdk:"log_level_out"`
+}
+
+type rulesModel struct {
+	ID    types.String   `tfsdk:"id"`
+	Node  types.String   `tfsdk:"node"`
+	VmId  types.Int64    `tfsdk:"vm_id"`
+	Rules []fw.RuleModel `tfsdk:"rules"`
+}
+
+type ipSetModel struct {
+	ID      types.String         `tfsdk:"id"`
+	Node    types.String         `tfsdk:"node"`
+	VmId    types.Int64          `tfsdk:"vm_id"`
+	Name    types.String         `tfsdk:"name"`
+	Comment types.String         `tfsdk:"comment"`
+	Entries []fw.IPSetEntryModel `tfsdk:"entries"`
+}
+
+type aliasModel struct {
+	ID      types.String `tfsdk:"id"`
+	Node    types.String `tfsdk:"node"`
+	VmId    types.Int64  `tfsdk:"vm_id"`
+	Name    types.String `tfsdk:"name"`
+	CIDR    types.String `tfsdk:"cidr"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+func formId(node string, vmId int) string {
+	return fmt.Sprintf("%s/%d", node, vmId)
+}
+
+func formNamedId(node string, vmId int, name string) string {
+	return fmt.Sprintf("%s/%d/%s", node, vmId, name)
+}
+
+func unpackId(id string) (string, int, error) {
+	s := strings.Split(id, "/")
+	if len(s) != 2 {
+		return "", 0, fmt.Errorf("invalid id %s, expected format `{node}/{vm_id}`", id)
+	}
+	vmId, err := strconv.Atoi(s[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid vm id %s: %w", s[1], err)
+	}
+	return s[0], vmId, nil
+}
+
+func unpackNamedId(id string) (string, int, string, error) {
+	s := strings.Split(id, "/")
+	if len(s) != 3 {
+		return "", 0, "", fmt.Errorf("invalid id %s, expected format `{node}/{vm_id}/{name}`", id)
+	}
+	vmId, err := strconv.Atoi(s[1])
+	if err != nil {
+		return "", 0, "", fmt.Errorf("invalid vm id %s: %w", s[1], err)
+	}
+	return s[0], vmId, s[2], nil
+}
+
+func boolOption(i *int, d bool) types.Bool {
+	if i == nil {
+		return types.BoolValue(d)
+	}
+	return types.BoolValue(service.IntToBool(*i))
+}
+
+func stringOption(s *string, d string) types.String {
+	if s == nil {
+		return types.StringValue(d)
+	}
+	return types.StringValue(*s)
+}
+
+// options proxmox does not return are at their defaults
+func OptionsToModel(node string, vmId int, o *service.VirtualMachineFirewallOptions) optionsModel {
+	return optionsModel{
+		ID:          types.StringValue(formId(node, vmId)),
+		Node:        types.StringValue(node),
+		VmId:        types.Int64Value(int64(vmId)),
+		Enabled:     boolOption(o.Enable, false),
+		PolicyIn:    stringOption(o.PolicyIn, "DROP"),
+		PolicyOut:   stringOption(o.PolicyOut, "ACCEPT"),
+		Dhcp:        boolOption(o.Dhcp, false),
+		Ndp:         boolOption(o.Ndp, false),
+		Radv:        boolOption(o.Radv, false),
+		IpFilter:    boolOption(o.IpFilter, false),
+		MacFilter:   boolOption(o.MacFilter, true),
+		LogLevelIn:  stringOption(o.LogLevelIn, "nolog"),
+		LogLevelOut: stringOption(o.LogLevelOut, "nolog"),
+	}
+}
+
+func (m *optionsModel) options() map[string]interface{} {
+	return map[string]interface{}{
+		"enable":        service.BoolToInt(m.Enabled.ValueBool()),
+		"policy_in":     m.PolicyIn.ValueString(),
+		"policy_out":    m.PolicyOut.ValueString(),
+		"dhcp":          service.BoolToInt(m.Dhcp.ValueBool()),
+		"ndp":           service.BoolToInt(m.Ndp.ValueBool()),
+		"radv":          service.BoolToInt(m.Radv.ValueBool()),
+		"ipfilter":      service.BoolToInt(m.IpFilter.ValueBool()),
+		"macfilter":     service.BoolToInt(m.MacFilter.ValueBool()),
+		"log_level_in":  m.LogLevelIn.ValueString(),
+		"log_level_out": m.LogLevelOut.ValueString(),
+	}
+}
+
+// options reset when the resource is deleted
+var optionKeys = []string{"enable", "policy_in", "policy_out", "dhcp", "ndp", "radv", "ipfilter", "macfilter", "log_level_in", "log_level_out"}
+
+func (m *aliasModel) alias() *service.FirewallAlias {
+	comment := m.Comment.ValueString()
+	return &service.FirewallAlias{
+		Name:    m.Name.ValueString(),
+		CIDR:    m.CIDR.ValueString(),
+		Comment: &comment,
+	}
+}
@@ -0,0 +1,181 @@
+package firewall
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &optionsResource{}
+	_ resource.ResourceWithConfigure   = &optionsResource{}
+	_ resource.ResourceWithImportState = &optionsResource{}
+)
+
+func OptionsResource() resource.Resource {
+	return &optionsResource{}
+}
+
+type optionsResource struct {
+	client *service.Proxmox
+}
+
+func (r *optionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_firewall_options"
+}
+
+func (r *optionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = optionsResourceSchema
+}
+
+func (r *optionsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *optionsResource) readOptionsModel(ctx context.Context, node string, vmId int) (*optionsModel, error) {
+	options, err := r.client.GetVirtualMachineFirewallOptions(ctx, node, vmId)
+	if err != nil {
+		return nil, err
+	}
+	m := OptionsToModel(node, vmId, options)
+	return &m, nil
+}
+
+func (r *optionsResource) apply(ctx context.Context, plan *optionsModel) (*optionsModel, error) {
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+
+	err := r.client.UpdateFirewallOptions(ctx, service.VirtualMachineFirewallPath(node, vmId), plan.options(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.readOptionsModel(ctx, node, vmId)
+}
+
+func (r *optionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create virtual machine firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure virtual machine firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read virtual machine firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, state.Node.ValueString(), int(state.VmId.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read virtual machine firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *optionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update virtual machine firewall options method")
+	var plan optionsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall options",
+			"Could not configure virtual machine firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// resets the options to their defaults, which disables the firewall
+func (r *optionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete virtual machine firewall options method")
+	var state optionsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	firewall := service.VirtualMachineFirewallPath(state.Node.ValueString(), int(state.VmId.ValueInt64()))
+	err := r.client.UpdateFirewallOptions(ctx, firewall, nil, optionKeys)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resetting firewall options",
+			"Could not reset virtual machine firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *optionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, vmId, err := unpackId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing firewall options",
+			err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readOptionsModel(ctx, node, vmId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall options",
+			"Could not read virtual machine firewall options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
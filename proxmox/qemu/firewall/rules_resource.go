@@ -0,0 +1,187 @@
+package firewall
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &rulesResource{}
+	_ resource.ResourceWithConfigure   = &rulesResource{}
+	_ resource.ResourceWithImportState = &rulesResource{}
+)
+
+func RulesResource() resource.Resource {
+	return &rulesResource{}
+}
+
+type rulesResource struct {
+	client *service.Proxmox
+}
+
+func (r *rulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_firewall_rules"
+}
+
+func (r *rulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = rulesResourceSchema
+}
+
+func (r *rulesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *rulesResource) readRulesModel(ctx context.Context, node string, vmId int) (*rulesModel, error) {
+	rules, err := fw.ReadRules(ctx, r.client, service.FirewallRulesPath(service.VirtualMachineFirewallPath(node, vmId)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &rulesModel{
+		ID:    types.StringValue(formId(node, vmId)),
+		Node:  types.StringValue(node),
+		VmId:  types.Int64Value(int64(vmId)),
+		Rules: rules,
+	}, nil
+}
+
+func (r *rulesResource) apply(ctx context.Context, plan *rulesModel) (*rulesModel, error) {
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+
+	err := fw.ReconcileRules(ctx, r.client, service.FirewallRulesPath(service.VirtualMachineFirewallPath(node, vmId)), plan.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return r.readRulesModel(ctx, node, vmId)
+}
+
+func (r *rulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create virtual machine firewall rules method")
+	var plan rulesModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall rules",
+			"Could not configure virtual machine firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read virtual machine firewall rules method")
+	var state rulesModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.readRulesModel(ctx, state.Node.ValueString(), int(state.VmId.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall rules",
+			"Could not read virtual machine firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update virtual machine firewall rules method")
+	var plan rulesModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.apply(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring firewall rules",
+			"Could not configure virtual machine firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *rulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete virtual machine firewall rules method")
+	var state rulesModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := service.FirewallRulesPath(service.VirtualMachineFirewallPath(state.Node.ValueString(), int(state.VmId.ValueInt64())))
+	err := fw.ReconcileRules(ctx, r.client, rules, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting firewall rules",
+			"Could not delete virtual machine firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *rulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, vmId, err := unpackId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing firewall rules",
+			err.Error(),
+		)
+		return
+	}
+
+	m, err := r.readRulesModel(ctx, node, vmId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading firewall rules",
+			"Could not read virtual machine firewall rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, m)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,160 @@
+package firewall
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	fw "github.com/awlsring/terraform-provider-proxmox/proxmox/firewall"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var nodeAttribute = rs.StringAttribute{
+	Required:    true,
+	Description: "The node the virtual machine is on.",
+	PlanModifiers: []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	},
+}
+
+var vmIdAttribute = rs.Int64Attribute{
+	Required:    true,
+	Description: "The identifier of the virtual machine.",
+	PlanModifiers: []planmodifier.Int64{
+		int64planmodifier.RequiresReplace(),
+	},
+	Validators: []validator.Int64{
+		int64validator.AtLeast(100),
+		int64validator.AtMost(999999999),
+	},
+}
+
+func idAttribute(description string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func boolOptionAttribute(description string, d bool) rs.BoolAttribute {
+	return rs.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.Bool{
+			defaults.DefaultBool(d),
+		},
+	}
+}
+
+func stringOptionAttribute(description string, d string, values []string) rs.StringAttribute {
+	return rs.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description,
+		PlanModifiers: []planmodifier.String{
+			defaults.DefaultString(d),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf(values...),
+		},
+	}
+}
+
+var optionsResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":            idAttribute("The id of the firewall options. Formatted as `{node}/{vm_id}`."),
+		"node":          nodeAttribute,
+		"vm_id":         vmIdAttribute,
+		"enabled":       boolOptionAttribute("Whether the firewall of the virtual machine is enabled. Network interfaces must also have `use_firewall` set.", true),
+		"policy_in":     stringOptionAttribute("The policy for incoming traffic that matches no rule.", "DROP", fw.Policies),
+		"policy_out":    stringOptionAttribute("The policy for outgoing traffic that matches no rule.", "ACCEPT", fw.Policies),
+		"dhcp":          boolOptionAttribute("Whether to allow DHCP.", false),
+		"ndp":           boolOptionAttribute("Whether to allow the IPv6 neighbor discovery protocol.", false),
+		"radv":          boolOptionAttribute("Whether to allow the virtual machine to send IPv6 router advertisements.", false),
+		"ipfilter":      boolOptionAttribute("Whether to drop traffic from addresses not configured on the virtual machine, using the `ipfilter-net*` ip sets.", false),
+		"macfilter":     boolOptionAttribute("Whether to drop traffic from MAC addresses not configured on the virtual machine.", true),
+		"log_level_in":  stringOptionAttribute("The log level for incoming traffic.", "nolog", fw.LogLevels),
+		"log_level_out": stringOptionAttribute("The log level for outgoing traffic.", "nolog", fw.LogLevels),
+	},
+}
+
+var rulesResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":    idAttribute("The id of the firewall rules. Formatted as `{node}/{vm_id}`."),
+		"node":  nodeAttribute,
+		"vm_id": vmIdAttribute,
+		"rules": rs.ListNestedAttribute{
+			Required:    true,
+			Description: "The firewall rules of the virtual machine, in the order they are evaluated. The resource manages every rule of the virtual machine.",
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: fw.RuleAttributes,
+			},
+		},
+	},
+}
+
+var ipSetResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":    idAttribute("The id of the ip set. Formatted as `{node}/{vm_id}/{name}`."),
+		"node":  nodeAttribute,
+		"vm_id": vmIdAttribute,
+		"name": rs.StringAttribute{
+			Required:    true,
+			Description: "The name of the ip set.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(fw.NameRegex, "name must start with a letter and only contain letters, numbers, `-` or `_`"),
+			},
+		},
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the ip set.",
+		},
+		"entries": rs.SetNestedAttribute{
+			Optional:    true,
+			Description: "The entries of the ip set.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+			NestedObject: rs.NestedAttributeObject{
+				Attributes: fw.IPSetEntryAttributes,
+			},
+		},
+	},
+}
+
+var aliasResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id":    idAttribute("The id of the alias. Formatted as `{node}/{vm_id}/{name}`."),
+		"node":  nodeAttribute,
+		"vm_id": vmIdAttribute,
+		"name": rs.StringAttribute{
+			Required:    true,
+			Description: "The name of the alias.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(fw.NameRegex, "name must start with a letter and only contain letters, numbers, `-` or `_`"),
+			},
+		},
+		"cidr": rs.StringAttribute{
+			Required:    true,
+			Description: "The address or CIDR the alias stands for.",
+		},
+		"comment": rs.StringAttribute{
+			Optional:    true,
+			Description: "Notes on the alias.",
+		},
+	},
+}
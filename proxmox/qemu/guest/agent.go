@@ -0,0 +1,38 @@
+package guest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// waits for locks held on the virtual machine, such as a running clone or configuration, and then
+// for the guest agent to respond
+func waitForGuest(ctx context.Context, client *service.Proxmox, node string, vmId int, deadline time.Time) error {
+	err := client.WaitForVirtualMachineLock(ctx, node, vmId, int64(time.Until(deadline).Seconds()))
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "waiting for guest agent...")
+	for {
+		err := client.PingVirtualMachineAgent(ctx, node, vmId)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for guest agent: %w", err)
+		}
+		tflog.Debug(ctx, "guest agent is not responding, waiting 5 seconds...")
+		time.Sleep(5 * time.Second)
+	}
+
+	return nil
+}
+
+func setDeadline(timeout int64) time.Time {
+	return time.Now().Add(time.Duration(timeout) * time.Second)
+}
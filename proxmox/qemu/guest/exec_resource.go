@@ -0,0 +1,158 @@
+package guest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &execResource{}
+	_ resource.ResourceWithConfigure = &execResource{}
+)
+
+func ExecResource() resource.Resource {
+	return &execResource{}
+}
+
+type execResource struct {
+	client *service.Proxmox
+}
+
+func (r *execResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_guest_exec"
+}
+
+func (r *execResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = execResourceSchema
+}
+
+func (r *execResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *execResource) exec(ctx context.Context, plan *execModel) (*service.GuestExecStatus, error) {
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+	deadline := setDeadline(plan.Timeout.ValueInt64())
+
+	err := waitForGuest(ctx, r.client, node, vmId, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	var input *string
+	if !plan.Input.IsNull() {
+		input = utils.OptionalToPointerString(plan.Input.ValueString())
+	}
+
+	command := utils.ListTypeToStringSlice(plan.Command)
+	tflog.Debug(ctx, fmt.Sprintf("running command %v in virtual machine %d", command, vmId))
+	pid, err := r.client.ExecVirtualMachineGuestCommand(ctx, node, vmId, command, input)
+	if err != nil {
+		return nil, err
+	}
+	plan.ID = types.StringValue(formExecId(node, vmId, pid))
+	plan.Pid = types.Int64Value(int64(pid))
+
+	for {
+		status, err := r.client.GetVirtualMachineGuestExecStatus(ctx, node, vmId, pid)
+		if err != nil {
+			return nil, err
+		}
+		if status.Exited {
+			return status, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for command to finish")
+		}
+		tflog.Debug(ctx, "command is still running, waiting 2 seconds...")
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func (r *execResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create guest exec method")
+	var plan execModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := r.exec(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running command",
+			"Could not run command in guest, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ExitCode = types.Int64Null()
+	if status.ExitCode != nil {
+		plan.ExitCode = types.Int64Value(int64(*status.ExitCode))
+	}
+	plan.Signal = types.Int64Null()
+	if status.Signal != nil {
+		plan.Signal = types.Int64Value(int64(*status.Signal))
+	}
+	plan.Stdout = types.StringValue(utils.PtrStringToString(status.OutData))
+	plan.Stderr = types.StringValue(utils.PtrStringToString(status.ErrData))
+
+	if plan.FailOnError.ValueBool() {
+		if status.Signal != nil {
+			resp.Diagnostics.AddError(
+				"Command failed",
+				fmt.Sprintf("Command was killed by signal %d.\n\nstdout:\n%s\n\nstderr:\n%s", *status.Signal, plan.Stdout.ValueString(), plan.Stderr.ValueString()),
+			)
+			return
+		}
+		if plan.ExitCode.ValueInt64() != 0 {
+			resp.Diagnostics.AddError(
+				"Command failed",
+				fmt.Sprintf("Command exited with code %d.\n\nstdout:\n%s\n\nstderr:\n%s", plan.ExitCode.ValueInt64(), plan.Stdout.ValueString(), plan.Stderr.ValueString()),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// the command has already run, there is nothing to refresh
+func (r *execResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read guest exec method")
+}
+
+// only the timeout and error handling can change without running the command again
+func (r *execResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update guest exec method")
+	var plan execModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *execResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete guest exec method")
+}
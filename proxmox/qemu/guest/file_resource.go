@@ -0,0 +1,147 @@
+package guest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &fileResource{}
+	_ resource.ResourceWithConfigure = &fileResource{}
+)
+
+func FileResource() resource.Resource {
+	return &fileResource{}
+}
+
+type fileResource struct {
+	client *service.Proxmox
+}
+
+func (r *fileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_guest_file"
+}
+
+func (r *fileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = fileResourceSchema
+}
+
+func (r *fileResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (r *fileResource) writeFile(ctx context.Context, plan *fileModel) error {
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+	path := plan.Path.ValueString()
+
+	err := waitForGuest(ctx, r.client, node, vmId, setDeadline(plan.Timeout.ValueInt64()))
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("writing file %s in virtual machine %d", path, vmId))
+	err = r.client.WriteVirtualMachineGuestFile(ctx, node, vmId, path, plan.Content.ValueString())
+	if err != nil {
+		return err
+	}
+
+	plan.ID = types.StringValue(formFileId(node, vmId, path))
+	plan.ContentHash = types.StringValue(service.ContentHash(plan.Content.ValueString()))
+	return nil
+}
+
+func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create guest file method")
+	var plan fileModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.writeFile(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing file",
+			"Could not write file in guest, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// refreshes the hash from the file in the guest, it is kept when the agent cannot be reached
+// so a stopped virtual machine does not cause a diff
+func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read guest file method")
+	var state fileModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := r.client.ReadVirtualMachineGuestFile(ctx, state.Node.ValueString(), int(state.VmId.ValueInt64()), state.Path.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not read file '%s' from guest: %v", state.ID.ValueString(), err))
+		return
+	}
+	state.ContentHash = types.StringValue(service.ContentHash(content))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update guest file method")
+	var plan fileModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state fileModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.ContentHash.Equal(state.ContentHash) {
+		err := r.writeFile(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error writing file",
+				"Could not write file in guest, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// the guest agent cannot delete files, the file is left in the guest
+func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete guest file method")
+}
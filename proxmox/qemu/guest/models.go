@@ -0,0 +1,42 @@
+package guest
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type execModel struct {
+	ID          types.String `tfsdk:"id"`
+	Node        types.String `tfsdk:"node"`
+	VmId        types.Int64  `tfsdk:"vm_id"`
+	Command     types.List   `tfsdk:"command"`
+	Input       types.String `tfsdk:"input"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+	FailOnError types.Bool   `tfsdk:"fail_on_error"`
+	Pid         types.Int64  `tfsdk:"pid"`
+	ExitCode    types.Int64  `tfsdk:"exit_code"`
+	Signal      types.Int64  `tfsdk:"signal"`
+	Stdout      types.String `tfsdk:"stdout"`
+	Stderr      types.String `tfsdk:"stderr"`
+}
+
+type fileModel struct {
+	ID          types.String `tfsdk:"id"`
+	Node        types.String `tfsdk:"node"`
+	VmId        types.Int64  `tfsdk:"vm_id"`
+	Path        types.String `tfsdk:"path"`
+	Content     types.String `tfsdk:"content"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+}
+
+func formExecId(node string, vmId int, pid int) string {
+	return fmt.Sprintf("%s/%d/%d", node, vmId, pid)
+}
+
+func formFileId(node string, vmId int, path string) string {
+	return fmt.Sprintf("%s/%d/%s", node, vmId, path)
+}
@@ -0,0 +1,183 @@
+package guest
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	qt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var nodeAttribute = rs.StringAttribute{
+	Required:    true,
+	Description: "The node the virtual machine is on.",
+	PlanModifiers: []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	},
+}
+
+var vmIdAttribute = rs.Int64Attribute{
+	Required:    true,
+	Description: "The identifier of the virtual machine. The guest agent must be enabled and running.",
+	PlanModifiers: []planmodifier.Int64{
+		int64planmodifier.RequiresReplace(),
+	},
+	Validators: []validator.Int64{
+		int64validator.AtLeast(100),
+		int64validator.AtMost(999999999),
+	},
+}
+
+var triggersAttribute = rs.MapAttribute{
+	Optional:    true,
+	Description: "Arbitrary values, changing any of them runs the resource again.",
+	ElementType: types.StringType,
+	PlanModifiers: []planmodifier.Map{
+		mapplanmodifier.RequiresReplace(),
+	},
+}
+
+var execResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the command. Formatted as `{node}/{vm_id}/{pid}`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"node":  nodeAttribute,
+		"vm_id": vmIdAttribute,
+		"command": rs.ListAttribute{
+			Required:    true,
+			Description: "The program and its arguments to run in the guest. The command is not run through a shell, use one explicitly such as `[\"/bin/sh\", \"-c\", \"...\"]` when needed.",
+			ElementType: types.StringType,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+		"input": rs.StringAttribute{
+			Optional:    true,
+			Description: "Data passed to the standard input of the command.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"triggers": triggersAttribute,
+		"timeout": rs.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "How long to wait in seconds for the guest agent and the command to finish.",
+			PlanModifiers: []planmodifier.Int64{
+				defaults.DefaultInt64(300),
+			},
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"fail_on_error": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether a non zero exit code fails the apply.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(true),
+			},
+		},
+		"pid": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The process id the command ran as in the guest.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"exit_code": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The exit code of the command. Unset when the command was killed by a signal.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"signal": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The signal that killed the command, if it was killed.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"stdout": rs.StringAttribute{
+			Computed:    true,
+			Description: "The standard output of the command.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"stderr": rs.StringAttribute{
+			Computed:    true,
+			Description: "The standard error of the command.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	},
+}
+
+var fileResourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the file. Formatted as `{node}/{vm_id}/{path}`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"node":  nodeAttribute,
+		"vm_id": vmIdAttribute,
+		"path": rs.StringAttribute{
+			Required:    true,
+			Description: "The absolute path of the file in the guest. Parent directories must already exist.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"content": rs.StringAttribute{
+			Required:    true,
+			Description: "The content of the file. The guest agent limits writes to 60KiB.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtMost(60 * 1024),
+			},
+		},
+		"content_hash": rs.StringAttribute{
+			Computed:    true,
+			Description: "The sha256 of the content. Changes made to the file inside the guest are detected through this hash while the agent is running.",
+			PlanModifiers: []planmodifier.String{
+				qt.ContentHash(),
+			},
+		},
+		"triggers": triggersAttribute,
+		"timeout": rs.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "How long to wait in seconds for the guest agent before writing the file.",
+			PlanModifiers: []planmodifier.Int64{
+				defaults.DefaultInt64(300),
+			},
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+	},
+}
@@ -0,0 +1,71 @@
+package schemas
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var cpuRangesRegex = regexp.MustCompile(`^\d+(-\d+)?(;\d+(-\d+)?)*$`)
+
+var CpuNumaNodeObjectSchema = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"cpus": schema.StringAttribute{
+			Required:    true,
+			Description: "The guest CPUs of the NUMA node, as ids or ranges separated by `;`, such as `0-3;8`.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(cpuRangesRegex, "cpus must be ids or ranges separated by `;`"),
+			},
+		},
+		"memory": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The memory of the NUMA node in MB.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"host_nodes": schema.StringAttribute{
+			Optional:    true,
+			Description: "The host NUMA nodes to allocate the memory from, as ids or ranges separated by `;`.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(cpuRangesRegex, "host_nodes must be ids or ranges separated by `;`"),
+			},
+		},
+		"policy": schema.StringAttribute{
+			Optional:    true,
+			Description: "The memory allocation policy on the host nodes.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"preferred",
+					"bind",
+					"interleave",
+				),
+			},
+		},
+	},
+}
+
+var CpuNumaNodeObjectDataSourceSchema = dschema.NestedAttributeObject{
+	Attributes: map[string]dschema.Attribute{
+		"cpus": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The guest CPUs of the NUMA node.",
+		},
+		"memory": dschema.Int64Attribute{
+			Computed:    true,
+			Description: "The memory of the NUMA node in MB.",
+		},
+		"host_nodes": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The host NUMA nodes the memory is allocated from.",
+		},
+		"policy": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The memory allocation policy on the host nodes.",
+		},
+	},
+}
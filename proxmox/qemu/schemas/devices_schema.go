@@ -0,0 +1,140 @@
+package schemas
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var RngAttributes = map[string]schema.Attribute{
+	"source": schema.StringAttribute{
+		Required:    true,
+		Description: "The host entropy source.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"/dev/urandom",
+				"/dev/random",
+				"/dev/hwrng",
+			),
+		},
+	},
+	"max_bytes": schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The maximum bytes of entropy injected into the guest every period. `0` disables the limit.",
+		PlanModifiers: []planmodifier.Int64{
+			defaults.DefaultInt64(1024),
+		},
+		Validators: []validator.Int64{
+			int64validator.AtLeast(0),
+		},
+	},
+	"period": schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The period in milliseconds that max_bytes applies to.",
+		PlanModifiers: []planmodifier.Int64{
+			defaults.DefaultInt64(1000),
+		},
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	},
+}
+
+var WatchdogAttributes = map[string]schema.Attribute{
+	"model": schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The emulated watchdog device.",
+		PlanModifiers: []planmodifier.String{
+			defaults.DefaultString("i6300esb"),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"i6300esb",
+				"ib700",
+			),
+		},
+	},
+	"action": schema.StringAttribute{
+		Optional:    true,
+		Description: "The action taken when the guest stops resetting the watchdog.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"reset",
+				"shutdown",
+				"poweroff",
+			),
+		},
+	},
+}
+
+var AudioAttributes = map[string]schema.Attribute{
+	"device": schema.StringAttribute{
+		Required:    true,
+		Description: "The emulated audio device.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"ich9-intel-hda",
+				"intel-hda",
+				"AC97",
+			),
+		},
+	},
+	"driver": schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The audio backend. `spice` plays the audio through the SPICE client.",
+		PlanModifiers: []planmodifier.String{
+			defaults.DefaultString("spice"),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"spice",
+				"none",
+			),
+		},
+	},
+}
+
+var RngDataSourceAttributes = map[string]dschema.Attribute{
+	"source": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The host entropy source.",
+	},
+	"max_bytes": dschema.Int64Attribute{
+		Computed:    true,
+		Description: "The maximum bytes of entropy injected into the guest every period.",
+	},
+	"period": dschema.Int64Attribute{
+		Computed:    true,
+		Description: "The period in milliseconds that max_bytes applies to.",
+	},
+}
+
+var WatchdogDataSourceAttributes = map[string]dschema.Attribute{
+	"model": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The emulated watchdog device.",
+	},
+	"action": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The action taken when the guest stops resetting the watchdog.",
+	},
+}
+
+var AudioDataSourceAttributes = map[string]dschema.Attribute{
+	"device": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The emulated audio device.",
+	},
+	"driver": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The audio backend.",
+	},
+}
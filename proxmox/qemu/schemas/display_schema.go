@@ -0,0 +1,115 @@
+package schemas
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var SerialDeviceObjectSchema = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"position": schema.Int64Attribute{
+			Required:    true,
+			Description: "The position of the serial device.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 3),
+			},
+		},
+		"type": schema.StringAttribute{
+			Required:    true,
+			Description: "The backend of the serial device. Either `socket` or the path of a host serial device, such as `/dev/ttyS0`.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(socket|/dev/.+)$`), "type must be `socket` or a path under `/dev/`"),
+			},
+		},
+	},
+}
+
+var SerialDeviceObjectDataSourceSchema = dschema.NestedAttributeObject{
+	Attributes: map[string]dschema.Attribute{
+		"position": dschema.Int64Attribute{
+			Computed:    true,
+			Description: "The position of the serial device.",
+		},
+		"type": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The backend of the serial device.",
+		},
+	},
+}
+
+var DisplayAttributes = map[string]schema.Attribute{
+	"type": schema.StringAttribute{
+		Required:    true,
+		Description: "The display type. The `qxl` types enable SPICE and `serial<n>` uses the serial device as the console.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"std",
+				"cirrus",
+				"vmware",
+				"qxl",
+				"qxl2",
+				"qxl3",
+				"qxl4",
+				"virtio",
+				"virtio-gl",
+				"serial0",
+				"serial1",
+				"serial2",
+				"serial3",
+				"none",
+			),
+		},
+	},
+	"memory": schema.Int64Attribute{
+		Optional:    true,
+		Description: "The display memory in MB.",
+		Validators: []validator.Int64{
+			int64validator.Between(4, 512),
+		},
+	},
+	"spice_folder_sharing": schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Whether to enable folder sharing over SPICE.",
+		PlanModifiers: []planmodifier.Bool{
+			defaults.DefaultBool(false),
+		},
+	},
+	"spice_video_streaming": schema.StringAttribute{
+		Optional:    true,
+		Description: "The SPICE video streaming mode.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(
+				"off",
+				"all",
+				"filter",
+			),
+		},
+	},
+}
+
+var DisplayDataSourceAttributes = map[string]dschema.Attribute{
+	"type": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The display type.",
+	},
+	"memory": dschema.Int64Attribute{
+		Computed:    true,
+		Description: "The display memory in MB.",
+	},
+	"spice_folder_sharing": dschema.BoolAttribute{
+		Computed:    true,
+		Description: "Whether folder sharing over SPICE is enabled.",
+	},
+	"spice_video_streaming": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The SPICE video streaming mode.",
+	},
+}
@@ -0,0 +1,107 @@
+package schemas
+
+import (
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var GuestNetworkInterfaceObjectSchema = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the interface in the guest.",
+		},
+		"mac_address": schema.StringAttribute{
+			Computed:    true,
+			Description: "The MAC address of the interface.",
+		},
+		"ipv4_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv4 addresses of the interface.",
+			ElementType: types.StringType,
+		},
+		"ipv6_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv6 addresses of the interface.",
+			ElementType: types.StringType,
+		},
+	},
+}
+
+var GuestNetworkInterfaceObjectDataSourceSchema = dschema.NestedAttributeObject{
+	Attributes: map[string]dschema.Attribute{
+		"name": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the interface in the guest.",
+		},
+		"mac_address": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The MAC address of the interface.",
+		},
+		"ipv4_addresses": dschema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv4 addresses of the interface.",
+			ElementType: types.StringType,
+		},
+		"ipv6_addresses": dschema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv6 addresses of the interface.",
+			ElementType: types.StringType,
+		},
+	},
+}
+
+var GuestOsInfoAttributes = map[string]schema.Attribute{
+	"id": schema.StringAttribute{
+		Computed:    true,
+		Description: "The identifier of the operating system.",
+	},
+	"name": schema.StringAttribute{
+		Computed:    true,
+		Description: "The name of the operating system.",
+	},
+	"pretty_name": schema.StringAttribute{
+		Computed:    true,
+		Description: "The full name of the operating system.",
+	},
+	"version": schema.StringAttribute{
+		Computed:    true,
+		Description: "The version of the operating system.",
+	},
+	"kernel_release": schema.StringAttribute{
+		Computed:    true,
+		Description: "The kernel release of the operating system.",
+	},
+	"machine": schema.StringAttribute{
+		Computed:    true,
+		Description: "The machine architecture reported by the operating system.",
+	},
+}
+
+var GuestOsInfoDataSourceAttributes = map[string]dschema.Attribute{
+	"id": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The identifier of the operating system.",
+	},
+	"name": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The name of the operating system.",
+	},
+	"pretty_name": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The full name of the operating system.",
+	},
+	"version": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The version of the operating system.",
+	},
+	"kernel_release": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The kernel release of the operating system.",
+	},
+	"machine": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The machine architecture reported by the operating system.",
+	},
+}
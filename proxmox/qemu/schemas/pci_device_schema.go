@@ -1,17 +1,24 @@
 package schemas
 
 import (
+	"regexp"
+
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 var PCIDeviceObjectSchema = schema.NestedAttributeObject{
 	Attributes: map[string]schema.Attribute{
 		"name": schema.StringAttribute{
 			Required:    true,
-			Description: "The device name of the PCI device.",
+			Description: "The device name of the PCI device. This determines the slot the device is passed through on.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile("^hostpci[0-9]$"), "name must follow scheme `hostpci<n>`"),
+			},
 		},
 		"id": schema.StringAttribute{
 			Required:    true,
@@ -0,0 +1,84 @@
+package schemas
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var USBDeviceObjectSchema = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"position": schema.Int64Attribute{
+			Required:    true,
+			Description: "The position of the USB device.",
+			Validators: []validator.Int64{
+				int64validator.Between(0, 13),
+			},
+		},
+		"host_id": schema.StringAttribute{
+			Optional:    true,
+			Description: "The vendor and product id of the host USB device, such as `1a86:7523`.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(0x)?[0-9a-fA-F]{4}:(0x)?[0-9a-fA-F]{4}$`), "host_id must be in the form `vendor:product`"),
+			},
+		},
+		"host_port": schema.StringAttribute{
+			Optional:    true,
+			Description: "The bus and port of the host USB device, such as `1-2.3`. Whatever is plugged into the port is passed through.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\d+-\d+(\.\d+)*$`), "host_port must be in the form `bus-port`"),
+			},
+		},
+		"spice": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Whether the device is redirected from the SPICE client. Can only be set to `true`.",
+		},
+		"mapping": schema.StringAttribute{
+			Optional:    true,
+			Description: "The name of the cluster USB hardware mapping.",
+		},
+		"usb3": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to attach the device to a USB3 controller.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+	},
+}
+
+var USBDeviceObjectDataSourceSchema = dschema.NestedAttributeObject{
+	Attributes: map[string]dschema.Attribute{
+		"position": dschema.Int64Attribute{
+			Computed:    true,
+			Description: "The position of the USB device.",
+		},
+		"host_id": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The vendor and product id of the host USB device.",
+		},
+		"host_port": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The bus and port of the host USB device.",
+		},
+		"spice": dschema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the device is redirected from the SPICE client.",
+		},
+		"mapping": dschema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the cluster USB hardware mapping.",
+		},
+		"usb3": dschema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the device is attached to a USB3 controller.",
+		},
+	},
+}
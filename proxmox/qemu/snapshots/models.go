@@ -0,0 +1,81 @@
+package snapshots
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type snapshotTimeoutsModel struct {
+	Create   types.Int64 `tfsdk:"create"`
+	Delete   types.Int64 `tfsdk:"delete"`
+	Rollback types.Int64 `tfsdk:"rollback"`
+}
+
+type snapshotModel struct {
+	ID               types.String           `tfsdk:"id"`
+	Node             types.String           `tfsdk:"node"`
+	VmId             types.Int64            `tfsdk:"vm_id"`
+	Name             types.String           `tfsdk:"name"`
+	Description      types.String           `tfsdk:"description"`
+	VmState          types.Bool             `tfsdk:"vmstate"`
+	FreezeFilesystem types.Bool             `tfsdk:"freeze_filesystem"`
+	RollbackOnCreate types.Bool             `tfsdk:"rollback_on_create"`
+	RollbackTrigger  types.String           `tfsdk:"rollback_trigger"`
+	Parent           types.String           `tfsdk:"parent"`
+	SnapshotTime     types.Int64            `tfsdk:"snapshot_time"`
+	Timeouts         *snapshotTimeoutsModel `tfsdk:"timeouts"`
+}
+
+func formId(node string, vmId int, name string) string {
+	return fmt.Sprintf("%s/%d/%s", node, vmId, name)
+}
+
+func unpackId(id string) (string, int, string, error) {
+	s := strings.Split(id, "/")
+	if len(s) != 3 {
+		return "", 0, "", fmt.Errorf("invalid id %s, expected format `{node}/{vm_id}/{name}`", id)
+	}
+	vmId, err := strconv.Atoi(s[1])
+	if err != nil {
+		return "", 0, "", fmt.Errorf("invalid vm id %s: %w", s[1], err)
+	}
+	return s[0], vmId, s[2], nil
+}
+
+func SnapshotToModel(node string, vmId int, snapshot *service.VirtualMachineSnapshot, state *snapshotModel) snapshotModel {
+	m := snapshotModel{
+		ID:      types.StringValue(formId(node, vmId, snapshot.Name)),
+		Node:    types.StringValue(node),
+		VmId:    types.Int64Value(int64(vmId)),
+		Name:    types.StringValue(snapshot.Name),
+		VmState: types.BoolValue(snapshot.VmState == 1),
+	}
+
+	if snapshot.Description != "" {
+		m.Description = types.StringValue(strings.TrimSuffix(snapshot.Description, "\n"))
+	}
+
+	if snapshot.Parent != nil {
+		m.Parent = types.StringValue(*snapshot.Parent)
+	}
+
+	if snapshot.SnapTime != nil {
+		m.SnapshotTime = types.Int64Value(*snapshot.SnapTime)
+	}
+
+	// carry over options that only affect how the snapshot is taken
+	m.FreezeFilesystem = types.BoolValue(false)
+	m.RollbackOnCreate = types.BoolValue(false)
+	if state != nil {
+		m.FreezeFilesystem = state.FreezeFilesystem
+		m.RollbackOnCreate = state.RollbackOnCreate
+		m.RollbackTrigger = state.RollbackTrigger
+		m.Timeouts = state.Timeouts
+	}
+
+	return m
+}
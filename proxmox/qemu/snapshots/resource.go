@@ -0,0 +1,368 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &snapshotResource{}
+	_ resource.ResourceWithConfigure   = &snapshotResource{}
+	_ resource.ResourceWithImportState = &snapshotResource{}
+)
+
+func Resource() resource.Resource {
+	return &snapshotResource{}
+}
+
+type snapshotResource struct {
+	client *service.Proxmox
+}
+
+func (r *snapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_machine_snapshot"
+}
+
+func (r *snapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema
+}
+
+func (r *snapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*service.Proxmox)
+}
+
+func (m *snapshotModel) createTimeout() int64 {
+	if m.Timeouts == nil {
+		return utils.DefaultTimeout
+	}
+	return utils.LoadTimeout(m.Timeouts.Create, utils.DefaultTimeout)
+}
+
+func (m *snapshotModel) deleteTimeout() int64 {
+	if m.Timeouts == nil {
+		return utils.DefaultTimeout
+	}
+	return utils.LoadTimeout(m.Timeouts.Delete, utils.DefaultTimeout)
+}
+
+func (m *snapshotModel) rollbackTimeout() int64 {
+	if m.Timeouts == nil {
+		return utils.DefaultTimeout
+	}
+	return utils.LoadTimeout(m.Timeouts.Rollback, utils.DefaultTimeout)
+}
+
+// waits for the task and the lock it holds on the virtual machine
+func (r *snapshotResource) waitForTask(ctx context.Context, node string, vmId int, upid string, timeout int64) error {
+	tflog.Debug(ctx, "waiting for task "+upid)
+	err := r.client.WaitForTask(ctx, node, upid, timeout)
+	if err != nil {
+		return err
+	}
+
+	return r.client.WaitForVirtualMachineLock(ctx, node, vmId, timeout)
+}
+
+func (r *snapshotResource) createSnapshot(ctx context.Context, plan *snapshotModel) error {
+	node := plan.Node.ValueString()
+	vmId := int(plan.VmId.ValueInt64())
+	timeout := plan.createTimeout()
+
+	err := r.client.WaitForVirtualMachineLock(ctx, node, vmId, timeout)
+	if err != nil {
+		return err
+	}
+
+	if plan.FreezeFilesystem.ValueBool() {
+		tflog.Debug(ctx, "freezing guest filesystems")
+		err = r.client.FreezeVirtualMachineFilesystems(ctx, node, vmId)
+		if err != nil {
+			return fmt.Errorf("could not freeze guest filesystems, is the guest agent running: %w", err)
+		}
+		defer func() {
+			tflog.Debug(ctx, "thawing guest filesystems")
+			thawErr := r.client.ThawVirtualMachineFilesystems(ctx, node, vmId)
+			if thawErr != nil {
+				tflog.Warn(ctx, "could not thaw guest filesystems: "+thawErr.Error())
+			}
+		}()
+	}
+
+	upid, err := r.client.CreateVirtualMachineSnapshot(ctx, &service.CreateVirtualMachineSnapshotInput{
+		Node:        node,
+		VmId:        vmId,
+		Name:        plan.Name.ValueString(),
+		Description: utils.OptionalToPointerString(plan.Description.ValueString()),
+		VmState:     plan.VmState.ValueBool(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.waitForTask(ctx, node, vmId, upid, timeout)
+}
+
+func (r *snapshotResource) rollbackSnapshot(ctx context.Context, model *snapshotModel) error {
+	node := model.Node.ValueString()
+	vmId := int(model.VmId.ValueInt64())
+	timeout := model.rollbackTimeout()
+
+	err := r.client.WaitForVirtualMachineLock(ctx, node, vmId, timeout)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Rolling back virtual machine %d to snapshot '%s'", vmId, model.Name.ValueString()))
+	upid, err := r.client.RollbackVirtualMachineSnapshot(ctx, node, vmId, model.Name.ValueString())
+	if err != nil {
+		return err
+	}
+
+	return r.waitForTask(ctx, node, vmId, upid, timeout)
+}
+
+func (r *snapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Create snapshot method")
+	var plan snapshotModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.createSnapshot(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot",
+			"Could not create snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if plan.RollbackOnCreate.ValueBool() {
+		err = r.rollbackSnapshot(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rolling back snapshot",
+				"Could not roll back to snapshot, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	snapshot, err := r.readSnapshotModel(ctx, formId(plan.Node.ValueString(), int(plan.VmId.ValueInt64()), plan.Name.ValueString()), &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Could not read snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if snapshot == nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Snapshot was not found after creation",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, snapshot)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// returns nil when the snapshot no longer exists
+func (r *snapshotResource) readSnapshotModel(ctx context.Context, id string, state *snapshotModel) (*snapshotModel, error) {
+	tflog.Debug(ctx, fmt.Sprintf("Reading snapshot model: %s", id))
+
+	node, vmId, name, err := unpackId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := r.client.ListVirtualMachineSnapshots(ctx, node, vmId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range snapshots {
+		if s.Name == name {
+			m := SnapshotToModel(node, vmId, &s, state)
+			return &m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Read snapshot method")
+	var state snapshotModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.readSnapshotModel(ctx, state.ID.ValueString(), &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Could not read snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if snapshot == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Snapshot '%s' no longer exists, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, snapshot)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Update snapshot method")
+	var plan snapshotModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state snapshotModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := state.Node.ValueString()
+	vmId := int(state.VmId.ValueInt64())
+
+	if !plan.Description.Equal(state.Description) {
+		tflog.Debug(ctx, "Updating snapshot description")
+		err := r.client.UpdateVirtualMachineSnapshotDescription(ctx, node, vmId, state.Name.ValueString(), plan.Description.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating snapshot",
+				"Could not update snapshot description, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.RollbackTrigger.IsNull() && !plan.RollbackTrigger.Equal(state.RollbackTrigger) {
+		err := r.rollbackSnapshot(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rolling back snapshot",
+				"Could not roll back to snapshot, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	snapshot, err := r.readSnapshotModel(ctx, state.ID.ValueString(), &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Could not read snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if snapshot == nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Snapshot no longer exists",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, snapshot)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Delete snapshot method")
+	var state snapshotModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := state.Node.ValueString()
+	vmId := int(state.VmId.ValueInt64())
+	timeout := state.deleteTimeout()
+
+	err := r.client.WaitForVirtualMachineLock(ctx, node, vmId, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot",
+			"Could not delete snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Deleting snapshot: '%s'", state.ID.ValueString()))
+	upid, err := r.client.DeleteVirtualMachineSnapshot(ctx, node, vmId, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot",
+			"Could not delete snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	err = r.waitForTask(ctx, node, vmId, upid, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot",
+			"Could not delete snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	model, err := r.readSnapshotModel(ctx, req.ID, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			"Could not read snapshot, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if model == nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			fmt.Sprintf("Snapshot '%s' does not exist", req.ID),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
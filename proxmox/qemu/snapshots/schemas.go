@@ -0,0 +1,119 @@
+package snapshots
+
+import (
+	"regexp"
+
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	rs "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var resourceSchema = rs.Schema{
+	Attributes: map[string]rs.Attribute{
+		"id": rs.StringAttribute{
+			Computed:    true,
+			Description: "The id of the snapshot. Formatted as `{node}/{vm_id}/{name}`.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"node": rs.StringAttribute{
+			Required:    true,
+			Description: "The node the virtual machine is on.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"vm_id": rs.Int64Attribute{
+			Required:    true,
+			Description: "The identifier of the virtual machine to snapshot.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+			Validators: []validator.Int64{
+				int64validator.AtLeast(100),
+				int64validator.AtMost(999999999),
+			},
+		},
+		"name": rs.StringAttribute{
+			Required:    true,
+			Description: "The name of the snapshot.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]{1,39}$`), "name must start with a letter and be 2 to 40 letters, numbers, `-` or `_`"),
+			},
+		},
+		"description": rs.StringAttribute{
+			Optional:    true,
+			Description: "The description of the snapshot.",
+		},
+		"vmstate": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to include the RAM of the running virtual machine in the snapshot.",
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplace(),
+				defaults.DefaultBool(false),
+			},
+		},
+		"freeze_filesystem": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to freeze the guest filesystems through the guest agent while the snapshot is taken.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+		"rollback_on_create": rs.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to roll the virtual machine back to the snapshot after creating it.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
+		"rollback_trigger": rs.StringAttribute{
+			Optional:    true,
+			Description: "An arbitrary value, changing it rolls the virtual machine back to the snapshot.",
+		},
+		"parent": rs.StringAttribute{
+			Computed:    true,
+			Description: "The name of the parent snapshot.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"snapshot_time": rs.Int64Attribute{
+			Computed:    true,
+			Description: "The time the snapshot was taken, as a unix timestamp.",
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"timeouts": rs.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]rs.Attribute{
+				"create": rs.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for creating the snapshot.",
+				},
+				"delete": rs.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for deleting the snapshot.",
+				},
+				"rollback": rs.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for rolling back to the snapshot.",
+				},
+			},
+		},
+	},
+}
@@ -28,6 +28,10 @@ var CloudInitDataSourceAttributes = map[string]dschema.Attribute{
 		Computed:   true,
 		Attributes: CloudInitDnsDataSourceAttributes,
 	},
+	"custom": dschema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: CloudInitCustomDataSourceAttributes,
+	},
 }
 
 var CloudInitUserDataSourceAttributes = map[string]dschema.Attribute{
@@ -111,6 +115,11 @@ var CloudInitAttributes = map[string]schema.Attribute{
 		Optional:   true,
 		Attributes: CloudInitDnsAttributes,
 	},
+	"custom": schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Custom cloud-init files replacing the generated ones, either existing snippet volumes or inline content.",
+		Attributes:  CloudInitCustomAttributes,
+	},
 }
 
 var CloudInitUserAttributes = map[string]schema.Attribute{
@@ -145,9 +154,10 @@ var CloudInitDnsAttributes = map[string]schema.Attribute{
 }
 
 type VirtualMachineCloudInitModel struct {
-	User *VirtualMachineCloudInitUserModel `tfsdk:"user"`
-	IP   CloudInitIpSetValue               `tfsdk:"ip"`
-	DNS  *VirtualMachineCloudInitDnsModel  `tfsdk:"dns"`
+	User   *VirtualMachineCloudInitUserModel   `tfsdk:"user"`
+	IP     CloudInitIpSetValue                 `tfsdk:"ip"`
+	DNS    *VirtualMachineCloudInitDnsModel    `tfsdk:"dns"`
+	Custom *VirtualMachineCloudInitCustomModel `tfsdk:"custom"`
 }
 
 type VirtualMachineCloudInitUserModel struct {
@@ -190,5 +200,7 @@ func CloudInitToModel(ctx context.Context, ci *vm.VirtualMachineCloudInit) *Virt
 		m.DNS = &dns
 	}
 
+	m.Custom = CloudInitCustomToModel(ci.Custom)
+
 	return &m
 }
@@ -0,0 +1,194 @@
+package types
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	CloudInitSnippetUser    = "user"
+	CloudInitSnippetNetwork = "network"
+	CloudInitSnippetVendor  = "vendor"
+	CloudInitSnippetMeta    = "meta"
+)
+
+var CloudInitSnippet = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"volume":       types.StringType,
+		"content":      types.StringType,
+		"content_hash": types.StringType,
+	},
+}
+
+var CloudInitCustom = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"storage":               types.StringType,
+		CloudInitSnippetUser:    CloudInitSnippet,
+		CloudInitSnippetNetwork: CloudInitSnippet,
+		CloudInitSnippetVendor:  CloudInitSnippet,
+		CloudInitSnippetMeta:    CloudInitSnippet,
+	},
+}
+
+var CloudInitCustomDataSourceAttributes = map[string]dschema.Attribute{
+	"storage": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The snippets storage inline content is uploaded to.",
+	},
+	CloudInitSnippetUser: dschema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: CloudInitSnippetDataSourceAttributes,
+	},
+	CloudInitSnippetNetwork: dschema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: CloudInitSnippetDataSourceAttributes,
+	},
+	CloudInitSnippetVendor: dschema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: CloudInitSnippetDataSourceAttributes,
+	},
+	CloudInitSnippetMeta: dschema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: CloudInitSnippetDataSourceAttributes,
+	},
+}
+
+var CloudInitSnippetDataSourceAttributes = map[string]dschema.Attribute{
+	"volume": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The snippet volume used for the cloud-init data.",
+	},
+	"content": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The inline content of the snippet.",
+	},
+	"content_hash": dschema.StringAttribute{
+		Computed:    true,
+		Description: "The sha256 of the inline content.",
+	},
+}
+
+var CloudInitCustomAttributes = map[string]schema.Attribute{
+	"storage": schema.StringAttribute{
+		Optional:    true,
+		Description: "The snippets enabled storage to upload inline content to. Required when content is set.",
+	},
+	CloudInitSnippetUser: schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The user data snippet.",
+		Attributes:  CloudInitSnippetAttributes,
+	},
+	CloudInitSnippetNetwork: schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The network data snippet.",
+		Attributes:  CloudInitSnippetAttributes,
+	},
+	CloudInitSnippetVendor: schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The vendor data snippet.",
+		Attributes:  CloudInitSnippetAttributes,
+	},
+	CloudInitSnippetMeta: schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The meta data snippet.",
+		Attributes:  CloudInitSnippetAttributes,
+	},
+}
+
+var CloudInitSnippetAttributes = map[string]schema.Attribute{
+	"volume": schema.StringAttribute{
+		Optional:    true,
+		Description: "An existing snippet volume to use, such as `local:snippets/user.yaml`.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("content")),
+		},
+	},
+	"content": schema.StringAttribute{
+		Optional:    true,
+		Description: "Inline content uploaded as a snippet to the custom storage.",
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtParent().AtName("storage")),
+		},
+	},
+	"content_hash": schema.StringAttribute{
+		Computed:    true,
+		Description: "The sha256 of the inline content. Changes made to the snippet outside of terraform are detected through this hash.",
+		PlanModifiers: []planmodifier.String{
+			ContentHash(),
+		},
+	},
+}
+
+type VirtualMachineCloudInitCustomModel struct {
+	Storage types.String                         `tfsdk:"storage"`
+	User    *VirtualMachineCloudInitSnippetModel `tfsdk:"user"`
+	Network *VirtualMachineCloudInitSnippetModel `tfsdk:"network"`
+	Vendor  *VirtualMachineCloudInitSnippetModel `tfsdk:"vendor"`
+	Meta    *VirtualMachineCloudInitSnippetModel `tfsdk:"meta"`
+}
+
+type VirtualMachineCloudInitSnippetModel struct {
+	Volume      types.String `tfsdk:"volume"`
+	Content     types.String `tfsdk:"content"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+func (m *VirtualMachineCloudInitSnippetModel) IsInline() bool {
+	return m != nil && !m.Content.IsNull()
+}
+
+// returns the snippets keyed by their cicustom kind
+func (m *VirtualMachineCloudInitCustomModel) Snippets() map[string]*VirtualMachineCloudInitSnippetModel {
+	return map[string]*VirtualMachineCloudInitSnippetModel{
+		CloudInitSnippetUser:    m.User,
+		CloudInitSnippetNetwork: m.Network,
+		CloudInitSnippetVendor:  m.Vendor,
+		CloudInitSnippetMeta:    m.Meta,
+	}
+}
+
+func (m *VirtualMachineCloudInitCustomModel) SetSnippet(kind string, snippet *VirtualMachineCloudInitSnippetModel) {
+	switch kind {
+	case CloudInitSnippetUser:
+		m.User = snippet
+	case CloudInitSnippetNetwork:
+		m.Network = snippet
+	case CloudInitSnippetVendor:
+		m.Vendor = snippet
+	case CloudInitSnippetMeta:
+		m.Meta = snippet
+	}
+}
+
+func CloudInitCustomToModel(custom *vm.VirtualMachineCloudInitCustom) *VirtualMachineCloudInitCustomModel {
+	if custom == nil {
+		return nil
+	}
+
+	snippet := func(volume *string) *VirtualMachineCloudInitSnippetModel {
+		if volume == nil {
+			return nil
+		}
+		return &VirtualMachineCloudInitSnippetModel{
+			Volume:      utils.StringToTfType(volume),
+			Content:     types.StringNull(),
+			ContentHash: types.StringNull(),
+		}
+	}
+
+	return &VirtualMachineCloudInitCustomModel{
+		Storage: types.StringNull(),
+		User:    snippet(custom.User),
+		Network: snippet(custom.Network),
+		Vendor:  snippet(custom.Vendor),
+		Meta:    snippet(custom.Meta),
+	}
+}
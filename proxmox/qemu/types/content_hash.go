@@ -0,0 +1,43 @@
+package types
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sets the hash of the sibling content attribute during plan, so content changed outside of
+// terraform shows up as a diff once the hash is refreshed
+func ContentHash() planmodifier.String {
+	return contentHashModifier{}
+}
+
+type contentHashModifier struct{}
+
+func (m contentHashModifier) Description(_ context.Context) string {
+	return "Sets the hash of the content attribute."
+}
+
+func (m contentHashModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m contentHashModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var content types.String
+	diags := req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("content"), &content)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case content.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case content.IsNull():
+		resp.PlanValue = types.StringNull()
+	default:
+		resp.PlanValue = types.StringValue(service.ContentHash(content.ValueString()))
+	}
+}
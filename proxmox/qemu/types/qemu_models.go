@@ -9,15 +9,79 @@ type VirtualMachineAgentModel struct {
 }
 
 type VirtualMachineCpuModel struct {
-	Architecture types.String `tfsdk:"architecture"`
-	Cores        types.Int64  `tfsdk:"cores"`
-	Sockets      types.Int64  `tfsdk:"sockets"`
-	EmulatedType types.String `tfsdk:"emulated_type"`
-	CPUUnits     types.Int64  `tfsdk:"cpu_units"`
+	Architecture types.String                     `tfsdk:"architecture"`
+	Cores        types.Int64                      `tfsdk:"cores"`
+	Sockets      types.Int64                      `tfsdk:"sockets"`
+	EmulatedType types.String                     `tfsdk:"emulated_type"`
+	CPUUnits     types.Int64                      `tfsdk:"cpu_units"`
+	CPULimit     types.Float64                    `tfsdk:"cpu_limit"`
+	VCPUs        types.Int64                      `tfsdk:"vcpus"`
+	Affinity     types.String                     `tfsdk:"affinity"`
+	Flags        types.Set                        `tfsdk:"flags"`
+	Hidden       types.Bool                       `tfsdk:"hidden"`
+	HvVendorId   types.String                     `tfsdk:"hv_vendor_id"`
+	Numa         types.Bool                       `tfsdk:"numa"`
+	NumaNodes    []VirtualMachineCpuNumaNodeModel `tfsdk:"numa_nodes"`
+}
+
+type VirtualMachineCpuNumaNodeModel struct {
+	Cpus      types.String `tfsdk:"cpus"`
+	Memory    types.Int64  `tfsdk:"memory"`
+	HostNodes types.String `tfsdk:"host_nodes"`
+	Policy    types.String `tfsdk:"policy"`
 }
 
 type VirtualMachineMemoryModel struct {
-	Dedicated types.Int64 `tfsdk:"dedicated"`
-	Floating  types.Int64 `tfsdk:"floating"`
-	Shared    types.Int64 `tfsdk:"shared"`
+	Dedicated types.Int64  `tfsdk:"dedicated"`
+	Floating  types.Int64  `tfsdk:"floating"`
+	Shared    types.Int64  `tfsdk:"shared"`
+	Hugepages types.String `tfsdk:"hugepages"`
+}
+
+type VirtualMachineEfiDiskModel struct {
+	Storage         types.String `tfsdk:"storage"`
+	Type            types.String `tfsdk:"type"`
+	PreEnrolledKeys types.Bool   `tfsdk:"pre_enrolled_keys"`
+}
+
+type VirtualMachineTpmStateModel struct {
+	Storage types.String `tfsdk:"storage"`
+	Version types.String `tfsdk:"version"`
+}
+
+type VirtualMachineSerialDeviceModel struct {
+	Position types.Int64  `tfsdk:"position"`
+	Type     types.String `tfsdk:"type"`
+}
+
+type VirtualMachineUSBDeviceModel struct {
+	Position types.Int64  `tfsdk:"position"`
+	HostId   types.String `tfsdk:"host_id"`
+	HostPort types.String `tfsdk:"host_port"`
+	Spice    types.Bool   `tfsdk:"spice"`
+	Mapping  types.String `tfsdk:"mapping"`
+	USB3     types.Bool   `tfsdk:"usb3"`
+}
+
+type VirtualMachineDisplayModel struct {
+	Type                types.String `tfsdk:"type"`
+	Memory              types.Int64  `tfsdk:"memory"`
+	SpiceFolderSharing  types.Bool   `tfsdk:"spice_folder_sharing"`
+	SpiceVideoStreaming types.String `tfsdk:"spice_video_streaming"`
+}
+
+type VirtualMachineRngModel struct {
+	Source   types.String `tfsdk:"source"`
+	MaxBytes types.Int64  `tfsdk:"max_bytes"`
+	Period   types.Int64  `tfsdk:"period"`
+}
+
+type VirtualMachineWatchdogModel struct {
+	Model  types.String `tfsdk:"model"`
+	Action types.String `tfsdk:"action"`
+}
+
+type VirtualMachineAudioModel struct {
+	Device types.String `tfsdk:"device"`
+	Driver types.String `tfsdk:"driver"`
 }
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var VirtualMachineCpuNumaNode = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"cpus":       types.StringType,
+		"memory":     types.Int64Type,
+		"host_nodes": types.StringType,
+		"policy":     types.StringType,
+	},
+}
+
+var VirtualMachineCpu = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"architecture":  types.StringType,
+		"cores":         types.Int64Type,
+		"sockets":       types.Int64Type,
+		"emulated_type": types.StringType,
+		"cpu_units":     types.Int64Type,
+		"cpu_limit":     types.Float64Type,
+		"vcpus":         types.Int64Type,
+		"affinity":      types.StringType,
+		"flags": types.SetType{
+			ElemType: types.StringType,
+		},
+		"hidden":       types.BoolType,
+		"hv_vendor_id": types.StringType,
+		"numa":         types.BoolType,
+		"numa_nodes": types.ListType{
+			ElemType: VirtualMachineCpuNumaNode,
+		},
+	},
+}
+
+var VirtualMachineMemory = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"dedicated": types.Int64Type,
+		"floating":  types.Int64Type,
+		"shared":    types.Int64Type,
+		"hugepages": types.StringType,
+	},
+}
+
+// sets the cpu options added on top of the base topology, shared by the resource and data source models
+func VMCPUOptionsToModel(cpu *vm.VirtualMachineCpu, m *VirtualMachineCpuModel) {
+	m.Hidden = types.BoolValue(cpu.Hidden)
+	m.Numa = types.BoolValue(cpu.Numa)
+	m.Flags = types.SetNull(types.StringType)
+	if len(cpu.Flags) > 0 {
+		m.Flags = utils.UnpackSetType(cpu.Flags)
+	}
+	if cpu.Limit != nil {
+		m.CPULimit = types.Float64Value(*cpu.Limit)
+	}
+	if cpu.VCpus != nil {
+		m.VCPUs = types.Int64Value(int64(*cpu.VCpus))
+	}
+	if cpu.Affinity != nil {
+		m.Affinity = types.StringValue(*cpu.Affinity)
+	}
+	if cpu.HvVendorId != nil {
+		m.HvVendorId = types.StringValue(*cpu.HvVendorId)
+	}
+	m.NumaNodes = VMNumaNodesToModel(cpu.NumaNodes)
+}
+
+func VMNumaNodesToModel(nodes []vm.VirtualMachineNumaNode) []VirtualMachineCpuNumaNodeModel {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	models := []VirtualMachineCpuNumaNodeModel{}
+	for _, n := range nodes {
+		m := VirtualMachineCpuNumaNodeModel{
+			Cpus: types.StringValue(n.Cpus),
+		}
+		if n.Memory != nil {
+			m.Memory = types.Int64Value(*n.Memory)
+		}
+		if n.HostNodes != nil {
+			m.HostNodes = types.StringValue(*n.HostNodes)
+		}
+		if n.Policy != nil {
+			m.Policy = types.StringValue(*n.Policy)
+		}
+		models = append(models, m)
+	}
+	return models
+}
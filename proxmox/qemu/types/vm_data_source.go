@@ -10,25 +10,36 @@ import (
 )
 
 type VirtualMachineDataSourceModel struct {
-	ID                types.Int64                            `tfsdk:"id"`
-	Node              types.String                           `tfsdk:"node"`
-	Name              types.String                           `tfsdk:"name"`
-	Description       types.String                           `tfsdk:"description"`
-	Tags              types.Set                              `tfsdk:"tags"`
-	Agent             *VirtualMachineAgentModel              `tfsdk:"agent"`
-	BIOS              types.String                           `tfsdk:"bios"`
-	CPU               VirtualMachineCpuModel                 `tfsdk:"cpu"`
-	Disks             VirtualMachineDiskSetValue             `tfsdk:"disks"`
-	PCIDevices        VirtualMachinePCIDeviceSetValue        `tfsdk:"pci_devices"`
-	NetworkInterfaces VirtualMachineNetworkInterfaceSetValue `tfsdk:"network_interfaces"`
-	Memory            VirtualMachineMemoryModel              `tfsdk:"memory"`
-	MachineType       types.String                           `tfsdk:"machine_type"`
-	KVMArguments      types.String                           `tfsdk:"kvm_arguments"`
-	KeyboardLayout    types.String                           `tfsdk:"keyboard_layout"`
-	CloudInit         *VirtualMachineCloudInitModel          `tfsdk:"cloud_init"`
-	Type              types.String                           `tfsdk:"type"`
-	ResourcePool      types.String                           `tfsdk:"resource_pool"`
-	StartOnNodeBoot   types.Bool                             `tfsdk:"start_on_node_boot"`
+	ID                     types.Int64                            `tfsdk:"id"`
+	Node                   types.String                           `tfsdk:"node"`
+	Name                   types.String                           `tfsdk:"name"`
+	Description            types.String                           `tfsdk:"description"`
+	Tags                   types.Set                              `tfsdk:"tags"`
+	Agent                  *VirtualMachineAgentModel              `tfsdk:"agent"`
+	BIOS                   types.String                           `tfsdk:"bios"`
+	CPU                    VirtualMachineCpuModel                 `tfsdk:"cpu"`
+	Disks                  VirtualMachineDiskSetValue             `tfsdk:"disks"`
+	PCIDevices             VirtualMachinePCIDeviceSetValue        `tfsdk:"pci_devices"`
+	SerialDevices          []VirtualMachineSerialDeviceModel      `tfsdk:"serial_devices"`
+	USBDevices             []VirtualMachineUSBDeviceModel         `tfsdk:"usb_devices"`
+	Display                *VirtualMachineDisplayModel            `tfsdk:"display"`
+	Rng                    *VirtualMachineRngModel                `tfsdk:"rng"`
+	Watchdog               *VirtualMachineWatchdogModel           `tfsdk:"watchdog"`
+	Audio                  *VirtualMachineAudioModel              `tfsdk:"audio"`
+	NetworkInterfaces      VirtualMachineNetworkInterfaceSetValue `tfsdk:"network_interfaces"`
+	IPv4Addresses          types.List                             `tfsdk:"ipv4_addresses"`
+	IPv6Addresses          types.List                             `tfsdk:"ipv6_addresses"`
+	GuestNetworkInterfaces types.List                             `tfsdk:"guest_network_interfaces"`
+	Hostname               types.String                           `tfsdk:"hostname"`
+	OsInfo                 types.Object                           `tfsdk:"os_info"`
+	Memory                 VirtualMachineMemoryModel              `tfsdk:"memory"`
+	MachineType            types.String                           `tfsdk:"machine_type"`
+	KVMArguments           types.String                           `tfsdk:"kvm_arguments"`
+	KeyboardLayout         types.String                           `tfsdk:"keyboard_layout"`
+	CloudInit              *VirtualMachineCloudInitModel          `tfsdk:"cloud_init"`
+	Type                   types.String                           `tfsdk:"type"`
+	ResourcePool           types.String                           `tfsdk:"resource_pool"`
+	StartOnNodeBoot        types.Bool                             `tfsdk:"start_on_node_boot"`
 }
 
 func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDataSourceModel {
@@ -44,6 +55,12 @@ func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDa
 		PCIDevices:        VirtualMachinePCIDeviceToSetValue(ctx, v.PCIDevices),
 		CloudInit:         CloudInitToModel(ctx, v.CloudInit),
 		StartOnNodeBoot:   types.BoolValue(v.StartOnBoot),
+		SerialDevices:     VMSerialDevicesToModel(v.SerialDevices),
+		USBDevices:        VMUSBDevicesToModel(v.USBDevices),
+		Display:           VMDisplayToModel(v.Display),
+		Rng:               VMRngToModel(v.Rng),
+		Watchdog:          VMWatchdogToModel(v.Watchdog),
+		Audio:             VMAudioToModel(v.Audio),
 	}
 
 	if v.Description != nil {
@@ -72,6 +89,13 @@ func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDa
 		m.Agent = &a
 	}
 
+	guest := VMGuestToModel(ctx, v.Guest)
+	m.IPv4Addresses = guest.IPv4Addresses
+	m.IPv6Addresses = guest.IPv6Addresses
+	m.GuestNetworkInterfaces = guest.NetworkInterfaces
+	m.Hostname = guest.Hostname
+	m.OsInfo = guest.OsInfo
+
 	return &m
 }
 
@@ -86,6 +110,30 @@ func VMAgentToModel(agent *vm.VirtualMachineAgent) VirtualMachineAgentModel {
 	return m
 }
 
+func VMEfiDiskToModel(disk *vm.VirtualMachineDisk) VirtualMachineEfiDiskModel {
+	m := VirtualMachineEfiDiskModel{
+		Storage:         types.StringValue(disk.Storage),
+		Type:            types.StringValue("2m"),
+		PreEnrolledKeys: types.BoolValue(disk.PreEnrolledKeys),
+	}
+	// proxmox omits the efi type for disks created before 4m was available
+	if disk.EfiType != nil {
+		m.Type = types.StringValue(*disk.EfiType)
+	}
+	return m
+}
+
+func VMTpmStateToModel(disk *vm.VirtualMachineDisk) VirtualMachineTpmStateModel {
+	m := VirtualMachineTpmStateModel{
+		Storage: types.StringValue(disk.Storage),
+		Version: types.StringValue("v1.2"),
+	}
+	if disk.TpmVersion != nil {
+		m.Version = types.StringValue(*disk.TpmVersion)
+	}
+	return m
+}
+
 func VMCPUToModel(cpu *vm.VirtualMachineCpu) VirtualMachineCpuModel {
 	m := VirtualMachineCpuModel{
 		Architecture: types.StringValue(string(cpu.Architecture)),
@@ -98,6 +146,7 @@ func VMCPUToModel(cpu *vm.VirtualMachineCpu) VirtualMachineCpuModel {
 	if cpu.CpuUnits != nil {
 		m.CPUUnits = types.Int64Value(int64(*cpu.CpuUnits))
 	}
+	VMCPUOptionsToModel(cpu, &m)
 
 	return m
 }
@@ -115,5 +164,9 @@ func VMMemoryToModel(memory *vm.VirtualMachineMemory) VirtualMachineMemoryModel
 		m.Shared = types.Int64Value(int64(*memory.Shared))
 	}
 
+	if memory.Hugepages != nil {
+		m.Hugepages = types.StringValue(*memory.Hugepages)
+	}
+
 	return m
 }
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var VirtualMachineRng = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"source":    types.StringType,
+		"max_bytes": types.Int64Type,
+		"period":    types.Int64Type,
+	},
+}
+
+var VirtualMachineWatchdog = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"model":  types.StringType,
+		"action": types.StringType,
+	},
+}
+
+var VirtualMachineAudio = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"device": types.StringType,
+		"driver": types.StringType,
+	},
+}
+
+func VMRngToModel(rng *vm.VirtualMachineRng) *VirtualMachineRngModel {
+	if rng == nil {
+		return nil
+	}
+
+	return &VirtualMachineRngModel{
+		Source:   types.StringValue(rng.Source),
+		MaxBytes: types.Int64Value(rng.MaxBytes),
+		Period:   types.Int64Value(rng.Period),
+	}
+}
+
+func VMWatchdogToModel(watchdog *vm.VirtualMachineWatchdog) *VirtualMachineWatchdogModel {
+	if watchdog == nil {
+		return nil
+	}
+
+	m := VirtualMachineWatchdogModel{
+		Model: types.StringValue(watchdog.Model),
+	}
+	if watchdog.Action != nil {
+		m.Action = types.StringValue(*watchdog.Action)
+	}
+	return &m
+}
+
+func VMAudioToModel(audio *vm.VirtualMachineAudio) *VirtualMachineAudioModel {
+	if audio == nil {
+		return nil
+	}
+
+	return &VirtualMachineAudioModel{
+		Device: types.StringValue(audio.Device),
+		Driver: types.StringValue(audio.Driver),
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var VirtualMachineSerialDevice = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"position": types.Int64Type,
+		"type":     types.StringType,
+	},
+}
+
+var VirtualMachineDisplay = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":                  types.StringType,
+		"memory":                types.Int64Type,
+		"spice_folder_sharing":  types.BoolType,
+		"spice_video_streaming": types.StringType,
+	},
+}
+
+func VMSerialDevicesToModel(devices []vm.VirtualMachineSerialDevice) []VirtualMachineSerialDeviceModel {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	models := []VirtualMachineSerialDeviceModel{}
+	for _, d := range devices {
+		models = append(models, VirtualMachineSerialDeviceModel{
+			Position: types.Int64Value(int64(d.Position)),
+			Type:     types.StringValue(d.Type),
+		})
+	}
+	return models
+}
+
+func VMDisplayToModel(display *vm.VirtualMachineDisplay) *VirtualMachineDisplayModel {
+	if display == nil {
+		return nil
+	}
+
+	m := VirtualMachineDisplayModel{
+		Type:               types.StringValue(display.Type),
+		SpiceFolderSharing: types.BoolValue(display.SpiceFolderSharing),
+	}
+	if display.Memory != nil {
+		m.Memory = types.Int64Value(int64(*display.Memory))
+	}
+	if display.SpiceVideoStreaming != nil {
+		m.SpiceVideoStreaming = types.StringValue(*display.SpiceVideoStreaming)
+	}
+	return &m
+}
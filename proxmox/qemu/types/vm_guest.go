@@ -0,0 +1,100 @@
+package types
+
+import (
+	"context"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var VirtualMachineGuestNetworkInterface = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":        types.StringType,
+		"mac_address": types.StringType,
+		"ipv4_addresses": types.ListType{
+			ElemType: types.StringType,
+		},
+		"ipv6_addresses": types.ListType{
+			ElemType: types.StringType,
+		},
+	},
+}
+
+var VirtualMachineGuestOsInfo = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":             types.StringType,
+		"name":           types.StringType,
+		"pretty_name":    types.StringType,
+		"version":        types.StringType,
+		"kernel_release": types.StringType,
+		"machine":        types.StringType,
+	},
+}
+
+type VirtualMachineGuestNetworkInterfaceModel struct {
+	Name          types.String `tfsdk:"name"`
+	MacAddress    types.String `tfsdk:"mac_address"`
+	IPv4Addresses types.List   `tfsdk:"ipv4_addresses"`
+	IPv6Addresses types.List   `tfsdk:"ipv6_addresses"`
+}
+
+type VirtualMachineGuestOsInfoModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	PrettyName    types.String `tfsdk:"pretty_name"`
+	Version       types.String `tfsdk:"version"`
+	KernelRelease types.String `tfsdk:"kernel_release"`
+	Machine       types.String `tfsdk:"machine"`
+}
+
+// the values reported by the guest agent, null when the agent is not running
+type VirtualMachineGuestModel struct {
+	IPv4Addresses     types.List
+	IPv6Addresses     types.List
+	NetworkInterfaces types.List
+	Hostname          types.String
+	OsInfo            types.Object
+}
+
+func VMGuestToModel(ctx context.Context, guest *vm.VirtualMachineGuest) VirtualMachineGuestModel {
+	m := VirtualMachineGuestModel{
+		IPv4Addresses:     types.ListNull(types.StringType),
+		IPv6Addresses:     types.ListNull(types.StringType),
+		NetworkInterfaces: types.ListNull(VirtualMachineGuestNetworkInterface),
+		Hostname:          types.StringNull(),
+		OsInfo:            types.ObjectNull(VirtualMachineGuestOsInfo.AttrTypes),
+	}
+	if guest == nil {
+		return m
+	}
+
+	m.IPv4Addresses = utils.UnpackListType(guest.IPv4Addresses())
+	m.IPv6Addresses = utils.UnpackListType(guest.IPv6Addresses())
+	m.Hostname = utils.StringToTfType(guest.Hostname)
+
+	interfaces := []VirtualMachineGuestNetworkInterfaceModel{}
+	for _, iface := range guest.NetworkInterfaces {
+		interfaces = append(interfaces, VirtualMachineGuestNetworkInterfaceModel{
+			Name:          types.StringValue(iface.Name),
+			MacAddress:    utils.StringToTfType(iface.MacAddress),
+			IPv4Addresses: utils.UnpackListType(iface.IPv4Addresses),
+			IPv6Addresses: utils.UnpackListType(iface.IPv6Addresses),
+		})
+	}
+	m.NetworkInterfaces, _ = types.ListValueFrom(ctx, VirtualMachineGuestNetworkInterface, interfaces)
+
+	if guest.OsInfo != nil {
+		m.OsInfo, _ = types.ObjectValueFrom(ctx, VirtualMachineGuestOsInfo.AttrTypes, VirtualMachineGuestOsInfoModel{
+			ID:            utils.StringToTfType(guest.OsInfo.Id),
+			Name:          utils.StringToTfType(guest.OsInfo.Name),
+			PrettyName:    utils.StringToTfType(guest.OsInfo.PrettyName),
+			Version:       utils.StringToTfType(guest.OsInfo.Version),
+			KernelRelease: utils.StringToTfType(guest.OsInfo.KernelRelease),
+			Machine:       utils.StringToTfType(guest.OsInfo.Machine),
+		})
+	}
+
+	return m
+}
@@ -27,26 +27,33 @@ var VirtualMachineDataSourceType = types.ObjectType{
 				"type":       types.StringType,
 			},
 		},
-		"bios": types.StringType,
-		"cpu": types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"architecture":  types.StringType,
-				"cores":         types.Int64Type,
-				"sockets":       types.Int64Type,
-				"emulated_type": types.StringType,
-				"cpu_units":     types.Int64Type,
-			},
-		},
+		"bios":               types.StringType,
+		"cpu":                VirtualMachineCpu,
 		"disks":              NewVirtualMachineDiskSetType(),
 		"network_interfaces": NewVirtualMachineNetworkInterfaceSetType(),
 		"pci_devices":        NewVirtualMachinePCIDeviceSetType(),
-		"memory": types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"dedicated": types.Int64Type,
-				"floating":  types.Int64Type,
-				"shared":    types.Int64Type,
-			},
+		"serial_devices": types.SetType{
+			ElemType: VirtualMachineSerialDevice,
+		},
+		"usb_devices": types.SetType{
+			ElemType: VirtualMachineUSBDevice,
+		},
+		"display":  VirtualMachineDisplay,
+		"rng":      VirtualMachineRng,
+		"watchdog": VirtualMachineWatchdog,
+		"audio":    VirtualMachineAudio,
+		"ipv4_addresses": types.ListType{
+			ElemType: types.StringType,
+		},
+		"ipv6_addresses": types.ListType{
+			ElemType: types.StringType,
+		},
+		"guest_network_interfaces": types.ListType{
+			ElemType: VirtualMachineGuestNetworkInterface,
 		},
+		"hostname": types.StringType,
+		"os_info":  VirtualMachineGuestOsInfo,
+		"memory":   VirtualMachineMemory,
 		"cloud_init": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
 				"user": types.ObjectType{
@@ -65,6 +72,7 @@ var VirtualMachineDataSourceType = types.ObjectType{
 						"domain":     types.StringType,
 					},
 				},
+				"custom": CloudInitCustom,
 			},
 		},
 		"machine_type":       types.StringType,
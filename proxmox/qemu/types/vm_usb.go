@@ -0,0 +1,46 @@
+package types
+
+import (
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var VirtualMachineUSBDevice = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"position":  types.Int64Type,
+		"host_id":   types.StringType,
+		"host_port": types.StringType,
+		"spice":     types.BoolType,
+		"mapping":   types.StringType,
+		"usb3":      types.BoolType,
+	},
+}
+
+func VMUSBDevicesToModel(devices []vm.VirtualMachineUSBDevice) []VirtualMachineUSBDeviceModel {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	models := []VirtualMachineUSBDeviceModel{}
+	for _, d := range devices {
+		m := VirtualMachineUSBDeviceModel{
+			Position: types.Int64Value(int64(d.Position)),
+			USB3:     types.BoolValue(d.USB3),
+		}
+		if d.HostId != nil {
+			m.HostId = types.StringValue(*d.HostId)
+		}
+		if d.HostPort != nil {
+			m.HostPort = types.StringValue(*d.HostPort)
+		}
+		if d.Spice {
+			m.Spice = types.BoolValue(true)
+		}
+		if d.Mapping != nil {
+			m.Mapping = types.StringValue(*d.Mapping)
+		}
+		models = append(models, m)
+	}
+	return models
+}
@@ -20,6 +20,18 @@ func authCreateValidator(ctx context.Context, isRoot bool, model *vt.VirtualMach
 	if !model.CPU.Architecture.IsNull() && !model.CPU.Architecture.IsUnknown() {
 		resp.Diagnostics.AddError("Root only property set", "The field cpu.architecture is only allowed to be set by root users")
 	}
+	if len(model.PCIDevices.PCIDevices) > 0 {
+		resp.Diagnostics.AddError("Root only property set", "The field pci_devices is only allowed to be set by root users")
+	}
+	if !model.CPU.Affinity.IsNull() && !model.CPU.Affinity.IsUnknown() {
+		resp.Diagnostics.AddError("Root only property set", "The field cpu.affinity is only allowed to be set by root users")
+	}
+	for _, d := range model.USBDevices {
+		if !d.HostId.IsNull() || !d.HostPort.IsNull() {
+			resp.Diagnostics.AddError("Root only property set", "The fields usb_devices.host_id and usb_devices.host_port are only allowed to be set by root users, use a mapping instead")
+			break
+		}
+	}
 }
 
 func authUpdateValidator(ctx context.Context, isRoot bool, model *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
@@ -31,4 +43,16 @@ func authUpdateValidator(ctx context.Context, isRoot bool, model *vt.VirtualMach
 	if model.CloudInit != nil {
 		resp.Diagnostics.AddError("Root only property set", "A current bug prevents non-root users from using cloud-init https://lists.proxmox.com/pipermail/pve-devel/2023-March/056155.html")
 	}
+	if len(model.PCIDevices.PCIDevices) > 0 {
+		resp.Diagnostics.AddError("Root only property set", "The field pci_devices is only allowed to be set by root users")
+	}
+	if !model.CPU.Affinity.IsNull() && !model.CPU.Affinity.IsUnknown() {
+		resp.Diagnostics.AddError("Root only property set", "The field cpu.affinity is only allowed to be set by root users")
+	}
+	for _, d := range model.USBDevices {
+		if !d.HostId.IsNull() || !d.HostPort.IsNull() {
+			resp.Diagnostics.AddError("Root only property set", "The fields usb_devices.host_id and usb_devices.host_port are only allowed to be set by root users, use a mapping instead")
+			break
+		}
+	}
 }
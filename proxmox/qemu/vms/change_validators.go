@@ -3,11 +3,15 @@ package vms
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/awlsring/terraform-provider-proxmox/internal/service"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
@@ -33,10 +37,20 @@ func isDiskInList(disk types.VirtualMachineDiskModel, list []types.VirtualMachin
 	return false
 }
 
+// returns the disk attached at the same interface and position, if there is one
+func findDiskAtPosition(disk types.VirtualMachineDiskModel, list []types.VirtualMachineDiskModel) (types.VirtualMachineDiskModel, bool) {
+	for _, d := range list {
+		if d.InterfaceType.ValueString() == disk.InterfaceType.ValueString() && d.Position.ValueInt64() == disk.Position.ValueInt64() {
+			return d, true
+		}
+	}
+	return types.VirtualMachineDiskModel{}, false
+}
+
 func changeValidatorDiskSize(_ context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
-	for i, disk := range plan.Disks.Disks {
-		previous := state.Disks.Disks[i]
-		if !disksAreSame(previous, disk) {
+	for _, disk := range plan.Disks.Disks {
+		previous, ok := findDiskAtPosition(disk, state.Disks.Disks)
+		if !ok || !disksAreSame(previous, disk) {
 			continue
 		}
 		if disk.Size.ValueInt64() < previous.Size.ValueInt64() {
@@ -47,9 +61,9 @@ func changeValidatorDiskSize(_ context.Context, state *vt.VirtualMachineResource
 }
 
 func changeValidatorDiskStorage(_ context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
-	for i, disk := range plan.Disks.Disks {
-		previous := state.Disks.Disks[i]
-		if disksAreSame(previous, disk) {
+	for _, disk := range plan.Disks.Disks {
+		previous, ok := findDiskAtPosition(disk, state.Disks.Disks)
+		if !ok || disksAreSame(previous, disk) {
 			continue
 		}
 		diskName := fmt.Sprintf("%s%v", disk.InterfaceType.ValueString(), disk.Position.ValueInt64())
@@ -76,6 +90,95 @@ func changeValidatorDiskRemoved(_ context.Context, state *vt.VirtualMachineResou
 	}
 }
 
+func efiDiskValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.EfiDisk == nil || plan.BIOS.IsUnknown() {
+		return
+	}
+	if plan.BIOS.ValueString() != "ovmf" {
+		resp.Diagnostics.AddAttributeError(path.Root("efi_disk"), "Invalid efi disk configuration", "An EFI disk can only be used with the `ovmf` BIOS")
+	}
+}
+
+func changeValidatorFirmwareDisks(_ context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if state.EfiDisk != nil && plan.EfiDisk != nil && !efiDisksAreSame(state.EfiDisk, plan.EfiDisk) {
+		resp.Diagnostics.AddWarning("Changing efi disk", "Detected a change to the EFI disk. The disk will be recreated and any stored UEFI variables, such as boot entries and enrolled keys, will be lost.")
+	}
+	if state.TpmState != nil && plan.TpmState != nil && !tpmStatesAreSame(state.TpmState, plan.TpmState) {
+		resp.Diagnostics.AddWarning("Changing tpm state", "Detected a change to the TPM state disk. The disk will be recreated and any secrets sealed by the TPM, such as disk encryption keys, will be lost.")
+	}
+}
+
+func displayValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	// unmanaged serial devices may still exist, such as ones inherited from a clone
+	if plan.Display == nil || plan.SerialDevices == nil {
+		return
+	}
+
+	displayType := plan.Display.Type.ValueString()
+	if !strings.HasPrefix(displayType, "serial") {
+		return
+	}
+	for _, d := range plan.SerialDevices {
+		if fmt.Sprintf("serial%v", d.Position.ValueInt64()) == displayType {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("display").AtName("type"), "Invalid display configuration", fmt.Sprintf("Display type %s requires a serial device at position %s", displayType, strings.TrimPrefix(displayType, "serial")))
+}
+
+func cpuValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	cpu := plan.CPU
+	if len(cpu.NumaNodes) > 0 && !cpu.Numa.IsUnknown() && !cpu.Numa.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("cpu").AtName("numa_nodes"), "Invalid cpu configuration", "cpu.numa_nodes requires cpu.numa to be enabled")
+	}
+	if !plan.Memory.Hugepages.IsNull() && !cpu.Numa.IsUnknown() && !cpu.Numa.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("memory").AtName("hugepages"), "Invalid memory configuration", "memory.hugepages requires cpu.numa to be enabled")
+	}
+
+	if cpu.VCPUs.IsNull() || cpu.VCPUs.IsUnknown() || cpu.Cores.IsUnknown() || cpu.Sockets.IsUnknown() {
+		return
+	}
+	total := cpu.Cores.ValueInt64() * cpu.Sockets.ValueInt64()
+	if cpu.VCPUs.ValueInt64() > total {
+		resp.Diagnostics.AddAttributeError(path.Root("cpu").AtName("vcpus"), "Invalid cpu configuration", fmt.Sprintf("cpu.vcpus (%d) must not exceed cores times sockets (%d)", cpu.VCPUs.ValueInt64(), total))
+	}
+}
+
+func usbDevicesValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	for _, d := range plan.USBDevices {
+		if d.HostId.IsUnknown() || d.HostPort.IsUnknown() || d.Spice.IsUnknown() || d.Mapping.IsUnknown() {
+			continue
+		}
+		device := fmt.Sprintf("usb%v", d.Position.ValueInt64())
+		if !d.Spice.IsNull() && !d.Spice.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("usb_devices"), "Invalid usb device configuration", fmt.Sprintf("USB device %s sets spice to false, remove the attribute instead", device))
+			continue
+		}
+		sources := 0
+		for _, set := range []bool{!d.HostId.IsNull(), !d.HostPort.IsNull(), !d.Spice.IsNull(), !d.Mapping.IsNull()} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("usb_devices"), "Invalid usb device configuration", fmt.Sprintf("USB device %s must set exactly one of host_id, host_port, spice or mapping", device))
+		}
+	}
+}
+
+func isHostUSBDevice(d types.VirtualMachineUSBDeviceModel) bool {
+	return !d.HostId.IsNull() || !d.HostPort.IsNull() || !d.Mapping.IsNull()
+}
+
+func idRangeValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.IDRange == nil {
+		return
+	}
+	if plan.IDRange.Start.ValueInt64() > plan.IDRange.End.ValueInt64() {
+		resp.Diagnostics.AddError("Invalid id range", fmt.Sprintf("The start of id_range (%d) must not be greater than the end (%d)", plan.IDRange.Start.ValueInt64(), plan.IDRange.End.ValueInt64()))
+	}
+}
+
 func powerOffValidator(ctx context.Context, client *service.Proxmox, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
 	isSensitive, err := isSensitivePropertyChanged(ctx, state, plan)
 	if err != nil {
@@ -84,5 +187,114 @@ func powerOffValidator(ctx context.Context, client *service.Proxmox, state *vt.V
 	}
 	if isSensitive {
 		resp.Diagnostics.AddWarning("Sensitive property changed", "Sensitive property changed. VM will be powered off to apply changes.")
+		return
+	}
+	if classifyChanges(ctx, state, plan) != changeRebootRequired {
+		return
+	}
+	if plan.RebootOnPendingChanges.ValueBool() {
+		resp.Diagnostics.AddWarning("Property requires reboot", "A changed property cannot be hotplugged. VM will be restarted to apply changes.")
+		return
+	}
+	resp.Diagnostics.AddWarning("Property requires reboot", "A changed property cannot be hotplugged. The change is left pending until the VM is restarted, set `reboot_on_pending_changes` to restart it automatically.")
+}
+
+func pxeValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.PXE == nil {
+		return
+	}
+
+	position := plan.PXE.NetworkInterface.ValueInt64()
+	found := false
+	for _, nic := range plan.NetworkInterfaces.Nics {
+		if nic.Position.ValueInt64() == position {
+			found = true
+		}
+	}
+	if !found {
+		resp.Diagnostics.AddError("Invalid pxe network interface", fmt.Sprintf("No network interface is configured at position %d to boot from", position))
+	}
+
+	if !plan.PXE.RevertBootOrder.ValueBool() {
+		return
+	}
+	if !plan.StartOnCreate.ValueBool() {
+		resp.Diagnostics.AddError("Invalid pxe configuration", "pxe.revert_boot_order requires start_on_create to be enabled")
+	}
+	if plan.Agent == nil || !plan.Agent.Enabled.ValueBool() {
+		resp.Diagnostics.AddError("Invalid pxe configuration", "pxe.revert_boot_order requires the agent to be enabled to detect the first boot")
+	}
+}
+
+func waitForIpValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.WaitForIP == nil {
+		return
+	}
+
+	if plan.Agent == nil || !plan.Agent.Enabled.ValueBool() {
+		resp.Diagnostics.AddError("Invalid wait_for_ip configuration", "wait_for_ip requires the agent to be enabled to read the guest addresses")
+	}
+
+	cidr := plan.WaitForIP.CIDR
+	if !cidr.IsNull() && !cidr.IsUnknown() {
+		if _, _, err := net.ParseCIDR(cidr.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Invalid wait_for_ip configuration", fmt.Sprintf("wait_for_ip.cidr '%s' is not a valid CIDR", cidr.ValueString()))
+		}
+	}
+}
+
+func templateValidator(_ context.Context, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if !plan.Template.ValueBool() {
+		return
+	}
+
+	if plan.WaitForIP != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_for_ip"), "Invalid template configuration", "wait_for_ip cannot be used on a template, templates are never started")
+	}
+	if plan.PXE != nil && plan.PXE.RevertBootOrder.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("pxe").AtName("revert_boot_order"), "Invalid template configuration", "pxe.revert_boot_order cannot be used on a template, templates are never started")
+	}
+}
+
+// devices that can be referenced in the boot order, state is nil when the virtual machine is being created
+func bootableDevices(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) []string {
+	disks := plan.Disks.Disks
+	nics := plan.NetworkInterfaces.Nics
+	if state != nil {
+		disks = append(append([]types.VirtualMachineDiskModel{}, disks...), state.ComputedDisks.Disks...)
+		nics = append(append([]types.VirtualMachineNetworkInterfaceModel{}, nics...), state.ComputedNetworkInterfaces.Nics...)
+	}
+
+	devices := []string{}
+	for _, d := range disks {
+		devices = append(devices, fmt.Sprintf("%s%v", d.InterfaceType.ValueString(), d.Position.ValueInt64()))
+	}
+	for _, n := range nics {
+		devices = append(devices, fmt.Sprintf("net%v", n.Position.ValueInt64()))
+	}
+	if plan.ISO != nil {
+		devices = append(devices, "ide2")
+	}
+	if plan.CloudImage != nil {
+		devices = append(devices, plan.CloudImage.Disk.ValueString())
+	}
+
+	return devices
+}
+
+func bootOrderValidator(_ context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.BootOrder.IsNull() || plan.BootOrder.IsUnknown() {
+		return
+	}
+	// devices inherited from the source are only known once the virtual machine exists
+	if state == nil && (plan.Clone != nil || plan.Restore != nil) {
+		return
+	}
+
+	devices := bootableDevices(state, plan)
+	for _, d := range utils.ListTypeToStringSlice(plan.BootOrder) {
+		if !utils.ListContains(devices, d) {
+			resp.Diagnostics.AddAttributeError(path.Root("boot_order"), "Invalid boot device", fmt.Sprintf("Boot device %s is not a disk or network interface of the virtual machine", d))
+		}
 	}
 }
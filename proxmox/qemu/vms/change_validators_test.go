@@ -0,0 +1,35 @@
+package vms
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeValidatorDisks(t *testing.T) {
+	ctx := context.Background()
+	state := newTestModel("pve1")
+	state.Disks.Disks = []qt.VirtualMachineDiskModel{testDisk("scsi", 0, "local-lvm", 20)}
+
+	added := newTestModel("pve1")
+	added.Disks.Disks = []qt.VirtualMachineDiskModel{testDisk("virtio", 0, "ceph", 10), testDisk("scsi", 0, "local-lvm", 20)}
+	resp := &resource.ModifyPlanResponse{}
+	changeValidatorDiskSize(ctx, state, added, resp)
+	changeValidatorDiskStorage(ctx, state, added, resp)
+	assert.Empty(t, resp.Diagnostics)
+
+	shrunk := newTestModel("pve1")
+	shrunk.Disks.Disks = []qt.VirtualMachineDiskModel{testDisk("virtio", 0, "ceph", 10), testDisk("scsi", 0, "local-lvm", 10)}
+	resp = &resource.ModifyPlanResponse{}
+	changeValidatorDiskSize(ctx, state, shrunk, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+
+	moved := newTestModel("pve1")
+	moved.Disks.Disks = []qt.VirtualMachineDiskModel{testDisk("scsi", 0, "ceph", 20)}
+	resp = &resource.ModifyPlanResponse{}
+	changeValidatorDiskStorage(ctx, state, moved, resp)
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+}
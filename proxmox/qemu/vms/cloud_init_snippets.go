@@ -0,0 +1,147 @@
+package vms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	ct "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func cloudInitSnippetFileName(vmId int, kind string) string {
+	return fmt.Sprintf("vm-%v-cloud-init-%s.yaml", vmId, kind)
+}
+
+func cloudInitCustomOf(model *vt.VirtualMachineResourceModel) *ct.VirtualMachineCloudInitCustomModel {
+	if model == nil || model.CloudInit == nil {
+		return nil
+	}
+	return model.CloudInit.Custom
+}
+
+func FormCloudInitCustomConfig(vmId int, custom *ct.VirtualMachineCloudInitCustomModel) *vm.VirtualMachineCloudInitCustom {
+	c := vm.VirtualMachineCloudInitCustom{}
+	for kind, snippet := range custom.Snippets() {
+		if snippet == nil {
+			continue
+		}
+
+		volume := snippet.Volume.ValueString()
+		if snippet.IsInline() {
+			volume = service.FormSnippetVolume(custom.Storage.ValueString(), cloudInitSnippetFileName(vmId, kind))
+		}
+
+		switch kind {
+		case ct.CloudInitSnippetUser:
+			c.User = &volume
+		case ct.CloudInitSnippetNetwork:
+			c.Network = &volume
+		case ct.CloudInitSnippetVendor:
+			c.Vendor = &volume
+		case ct.CloudInitSnippetMeta:
+			c.Meta = &volume
+		}
+	}
+	return &c
+}
+
+// uploads inline snippets whose content differs from what was last written
+func (r *virtualMachineResource) uploadCloudInitSnippets(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) error {
+	custom := cloudInitCustomOf(plan)
+	if custom == nil {
+		return nil
+	}
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+	storage := custom.Storage.ValueString()
+
+	old := cloudInitCustomOf(state)
+	for kind, snippet := range custom.Snippets() {
+		if !snippet.IsInline() {
+			continue
+		}
+
+		if old != nil && old.Storage.ValueString() == storage {
+			was := old.Snippets()[kind]
+			if was.IsInline() && was.ContentHash.ValueString() == service.ContentHash(snippet.Content.ValueString()) {
+				continue
+			}
+		}
+
+		fileName := cloudInitSnippetFileName(vmId, kind)
+		tflog.Debug(ctx, fmt.Sprintf("uploading cloud-init snippet %s to storage %s", fileName, storage))
+		err := r.client.UploadSnippet(ctx, node, storage, fileName, snippet.Content.ValueString())
+		if err != nil {
+			return fmt.Errorf("failed to upload cloud-init %s snippet: %w", kind, err)
+		}
+	}
+
+	return nil
+}
+
+// keeps the inline content from state and refreshes its hash from the uploaded file
+func (r *virtualMachineResource) readCloudInitSnippets(ctx context.Context, node string, vmId int, model *vt.VirtualMachineResourceModel, state *vt.VirtualMachineResourceModel) error {
+	was := cloudInitCustomOf(state)
+	if was == nil || model.CloudInit == nil {
+		return nil
+	}
+
+	is := model.CloudInit.Custom
+	if is == nil {
+		return nil
+	}
+	is.Storage = was.Storage
+	storage := was.Storage.ValueString()
+
+	for kind, snippet := range was.Snippets() {
+		if !snippet.IsInline() {
+			continue
+		}
+
+		current := is.Snippets()[kind]
+		fileName := cloudInitSnippetFileName(vmId, kind)
+		if current == nil || current.Volume.ValueString() != service.FormSnippetVolume(storage, fileName) {
+			continue
+		}
+
+		hash, err := r.client.SnippetHash(ctx, node, storage, fileName)
+		if err != nil {
+			return fmt.Errorf("failed to read cloud-init %s snippet: %w", kind, err)
+		}
+
+		is.SetSnippet(kind, &ct.VirtualMachineCloudInitSnippetModel{
+			Volume:      types.StringNull(),
+			Content:     snippet.Content,
+			ContentHash: types.StringValue(hash),
+		})
+	}
+
+	return nil
+}
+
+// removes uploaded snippets, failures are only logged as the virtual machine is already gone
+func (r *virtualMachineResource) deleteCloudInitSnippets(ctx context.Context, state *vt.VirtualMachineResourceModel) {
+	custom := cloudInitCustomOf(state)
+	if custom == nil {
+		return
+	}
+
+	node := state.Node.ValueString()
+	vmId := int(state.ID.ValueInt64())
+	for kind, snippet := range custom.Snippets() {
+		if !snippet.IsInline() {
+			continue
+		}
+
+		fileName := cloudInitSnippetFileName(vmId, kind)
+		err := r.client.DeleteSnippet(ctx, node, custom.Storage.ValueString(), fileName)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("could not delete cloud-init snippet %s: %v", fileName, err))
+		}
+	}
+}
@@ -3,10 +3,12 @@ package vms
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/awlsring/proxmox-go/proxmox"
 	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
 	ct "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
@@ -59,6 +61,11 @@ func (r *virtualMachineResource) determineVmConfigurations(ctx context.Context,
 	node := plan.Node.ValueString()
 	vmId := int(plan.ID.ValueInt64())
 
+	err = r.uploadCloudInitSnippets(ctx, state, plan)
+	if err != nil {
+		return err
+	}
+
 	tflog.Debug(ctx, "configure virtual machine updates request: "+utils.MarshalSafe(updates))
 	tflog.Debug(ctx, "configure virtual machine deletes request: "+utils.MarshalSafe(deletes))
 	tflog.Debug(ctx, "resize disk virtual machine request: "+utils.MarshalSafe(resizes))
@@ -106,7 +113,7 @@ func (r *virtualMachineResource) configureVm(ctx context.Context, node string, v
 	tflog.Debug(ctx, "configure virtual machine complete")
 
 	tflog.Debug(ctx, "waiting for lock")
-	err = r.waitForLock(ctx, node, vmId, r.timeouts.Configure)
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Configure)
 	if err != nil {
 		return err
 	}
@@ -121,7 +128,7 @@ func (r *virtualMachineResource) resizeDisk(ctx context.Context, node string, vm
 	}
 
 	tflog.Debug(ctx, "waiting for lock")
-	err = r.waitForLock(ctx, node, vmId, r.timeouts.Configure)
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Configure)
 	if err != nil {
 		return err
 	}
@@ -211,6 +218,7 @@ func determineUpdates(ctx context.Context, node string, vmId int, state *vt.Virt
 		Memory:            FormMemoryConfig(&plan.Memory),
 		CPU:               FormCPUConfig(&plan.CPU),
 		NetworkInterfaces: FormNetworkInterfaceConfig(plan.NetworkInterfaces.Nics),
+		PCIDevices:        FormPCIDeviceConfig(plan.PCIDevices.PCIDevices),
 	}
 
 	old := state
@@ -236,16 +244,49 @@ func determineUpdates(ctx context.Context, node string, vmId int, state *vt.Virt
 	}
 	request.Disks = append(request.Disks, FormDiskConfig(ctx, existingDisks, false)...)
 
+	// firmware disks cannot be reconfigured in place, changed ones are removed first in determineDeletes
+	if plan.EfiDisk != nil && !efiDisksAreSame(old.EfiDisk, plan.EfiDisk) {
+		request.EfiDisk = FormEfiDiskConfig(plan.EfiDisk)
+	}
+
+	if plan.TpmState != nil && !tpmStatesAreSame(old.TpmState, plan.TpmState) {
+		request.TpmState = FormTpmStateConfig(plan.TpmState)
+	}
+
 	if plan.Agent != nil {
 		request.Agent = FormAgentConfig(plan.Agent)
 	}
 
+	if plan.SerialDevices != nil {
+		request.SerialDevices = FormSerialDeviceConfig(plan.SerialDevices)
+	}
+
+	if plan.USBDevices != nil {
+		request.USBDevices = FormUSBDeviceConfig(plan.USBDevices)
+	}
+
+	if plan.Display != nil {
+		request.Display = FormDisplayConfig(plan.Display)
+	}
+
+	if plan.Rng != nil {
+		request.Rng = FormRngConfig(plan.Rng)
+	}
+
+	if plan.Watchdog != nil {
+		request.Watchdog = FormWatchdogConfig(plan.Watchdog)
+	}
+
+	if plan.Audio != nil {
+		request.Audio = FormAudioConfig(plan.Audio)
+	}
+
 	if !plan.BIOS.IsNull() {
 		request.Bios = FormBIOSConfig(plan.BIOS)
 	}
 
 	if plan.CloudInit != nil {
-		request.CloudInit = FormCloudInitConfig(ctx, plan.CloudInit)
+		request.CloudInit = FormCloudInitConfig(ctx, vmId, plan.CloudInit)
 	}
 
 	if !plan.Type.IsNull() {
@@ -266,6 +307,14 @@ func determineUpdates(ctx context.Context, node string, vmId int, state *vt.Virt
 		request.KeyboardLayout = &k
 	}
 
+	if !plan.Hotplug.IsNull() && !plan.Hotplug.IsUnknown() {
+		request.Hotplug = FormHotplugConfig(plan.Hotplug)
+	}
+
+	if !plan.BootOrder.IsNull() && !plan.BootOrder.IsUnknown() {
+		request.BootOrder = utils.ListTypeToStringSlice(plan.BootOrder)
+	}
+
 	if plan.StartOnNodeBoot.ValueBool() {
 		request.StartOnBoot = plan.StartOnNodeBoot.ValueBool()
 	}
@@ -302,11 +351,74 @@ func determineDeletes(ctx context.Context, node string, vmId int, state *vt.Virt
 		fieldsToDelete = append(fieldsToDelete, removedDisks...)
 	}
 
+	if old.EfiDisk != nil && plan.EfiDisk != nil && !efiDisksAreSame(old.EfiDisk, plan.EfiDisk) {
+		tflog.Debug(ctx, "efi disk changed, will recreate")
+		fieldsToDelete = append(fieldsToDelete, vm.DiskInterfaceEfi+"0")
+	}
+
+	if old.TpmState != nil && plan.TpmState != nil && !tpmStatesAreSame(old.TpmState, plan.TpmState) {
+		tflog.Debug(ctx, "tpm state changed, will recreate")
+		fieldsToDelete = append(fieldsToDelete, vm.DiskInterfaceTpm+"0")
+	}
+
+	removedCpuOptions := determineRemovedCpuOptions(ctx, &old.CPU, &plan.CPU)
+	if len(removedCpuOptions) > 0 {
+		fieldsToDelete = append(fieldsToDelete, removedCpuOptions...)
+	}
+
+	if !old.Memory.Hugepages.IsNull() && plan.Memory.Hugepages.IsNull() {
+		tflog.Debug(ctx, "hugepages are null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "hugepages")
+	}
+
+	removedSerialDevices := determineRemovedSerialDevices(ctx, old.SerialDevices, plan.SerialDevices)
+	if len(removedSerialDevices) > 0 {
+		fieldsToDelete = append(fieldsToDelete, removedSerialDevices...)
+	}
+
+	removedUSBDevices := determineRemovedUSBDevices(ctx, old.USBDevices, plan.USBDevices)
+	if len(removedUSBDevices) > 0 {
+		fieldsToDelete = append(fieldsToDelete, removedUSBDevices...)
+	}
+
+	if old.Display != nil && plan.Display != nil && hasSpiceEnhancements(old.Display) && !hasSpiceEnhancements(plan.Display) {
+		tflog.Debug(ctx, "spice enhancements are unset, will delete")
+		fieldsToDelete = append(fieldsToDelete, "spice_enhancements")
+	}
+
+	if old.Display != nil && plan.Display == nil {
+		tflog.Debug(ctx, "display is null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "vga")
+		if hasSpiceEnhancements(old.Display) {
+			fieldsToDelete = append(fieldsToDelete, "spice_enhancements")
+		}
+	}
+
+	if old.Rng != nil && plan.Rng == nil {
+		tflog.Debug(ctx, "rng is null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "rng0")
+	}
+
+	if old.Watchdog != nil && plan.Watchdog == nil {
+		tflog.Debug(ctx, "watchdog is null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "watchdog")
+	}
+
+	if old.Audio != nil && plan.Audio == nil {
+		tflog.Debug(ctx, "audio is null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "audio0")
+	}
+
 	removedNics := determineRemovedNetworkInterfaces(ctx, old.NetworkInterfaces.Nics, plan.NetworkInterfaces.Nics)
 	if len(removedNics) > 0 {
 		fieldsToDelete = append(fieldsToDelete, removedNics...)
 	}
 
+	removedPCIDevices := determineRemovedPCIDevices(ctx, old.PCIDevices.PCIDevices, plan.PCIDevices.PCIDevices)
+	if len(removedPCIDevices) > 0 {
+		fieldsToDelete = append(fieldsToDelete, removedPCIDevices...)
+	}
+
 	oldCfgs := []ct.VirtualMachineCloudInitIpModel{}
 	if old.CloudInit != nil {
 		if !plan.CloudInit.IP.IsNull() {
@@ -326,6 +438,11 @@ func determineDeletes(ctx context.Context, node string, vmId int, state *vt.Virt
 		fieldsToDelete = append(fieldsToDelete, removedIpCfg...)
 	}
 
+	if cloudInitCustomOf(old) != nil && cloudInitCustomOf(plan) == nil {
+		tflog.Debug(ctx, "cloud init custom is null, will delete")
+		fieldsToDelete = append(fieldsToDelete, "cicustom")
+	}
+
 	if len(fieldsToDelete) != 0 {
 		return &service.ConfigureVirtualMachineInput{
 			Node:   node,
@@ -392,6 +509,87 @@ func determineRemovedNetworkInterfaces(ctx context.Context, state []ct.VirtualMa
 	return removeNics
 }
 
+func determineRemovedSerialDevices(ctx context.Context, state []ct.VirtualMachineSerialDeviceModel, plan []ct.VirtualMachineSerialDeviceModel) []string {
+	// an unset plan leaves the serial devices unmanaged
+	if plan == nil {
+		return []string{}
+	}
+
+	planDevices := []string{}
+	for _, d := range plan {
+		planDevices = append(planDevices, fmt.Sprintf("serial%v", d.Position.ValueInt64()))
+	}
+
+	removed := []string{}
+	for _, d := range state {
+		device := fmt.Sprintf("serial%v", d.Position.ValueInt64())
+		if !utils.ListContains(planDevices, device) {
+			tflog.Debug(ctx, fmt.Sprintf("serial device %s was removed", device))
+			removed = append(removed, device)
+		}
+	}
+	return removed
+}
+
+func determineRemovedUSBDevices(ctx context.Context, state []ct.VirtualMachineUSBDeviceModel, plan []ct.VirtualMachineUSBDeviceModel) []string {
+	// an unset plan leaves the usb devices unmanaged
+	if plan == nil {
+		return []string{}
+	}
+
+	planDevices := []string{}
+	for _, d := range plan {
+		planDevices = append(planDevices, fmt.Sprintf("usb%v", d.Position.ValueInt64()))
+	}
+
+	removed := []string{}
+	for _, d := range state {
+		device := fmt.Sprintf("usb%v", d.Position.ValueInt64())
+		if !utils.ListContains(planDevices, device) {
+			tflog.Debug(ctx, fmt.Sprintf("usb device %s was removed", device))
+			removed = append(removed, device)
+		}
+	}
+	return removed
+}
+
+func determineRemovedCpuOptions(ctx context.Context, state *ct.VirtualMachineCpuModel, plan *ct.VirtualMachineCpuModel) []string {
+	removed := []string{}
+	if !state.CPULimit.IsNull() && plan.CPULimit.IsNull() {
+		removed = append(removed, "cpulimit")
+	}
+	if !state.VCPUs.IsNull() && plan.VCPUs.IsNull() {
+		removed = append(removed, "vcpus")
+	}
+	if !state.Affinity.IsNull() && plan.Affinity.IsNull() {
+		removed = append(removed, "affinity")
+	}
+	for i := len(plan.NumaNodes); i < len(state.NumaNodes); i++ {
+		removed = append(removed, fmt.Sprintf("numa%v", i))
+	}
+	if len(removed) > 0 {
+		tflog.Debug(ctx, fmt.Sprintf("cpu options %v were removed", removed))
+	}
+	return removed
+}
+
+func determineRemovedPCIDevices(ctx context.Context, state []ct.VirtualMachinePCIDeviceModel, plan []ct.VirtualMachinePCIDeviceModel) []string {
+	planDevices := []string{}
+	for _, device := range plan {
+		planDevices = append(planDevices, device.Name.ValueString())
+	}
+
+	removeDevices := []string{}
+	for _, device := range state {
+		if !utils.ListContains(planDevices, device.Name.ValueString()) {
+			tflog.Debug(ctx, fmt.Sprintf("pci device to remove: %s", device.Name.ValueString()))
+			removeDevices = append(removeDevices, device.Name.ValueString())
+		}
+	}
+
+	return removeDevices
+}
+
 func determineRemovedDisks(ctx context.Context, state []ct.VirtualMachineDiskModel, plan []ct.VirtualMachineDiskModel) []string {
 	stateDisks, planDisks := flattenDisks(ctx, state, plan)
 	tflog.Debug(ctx, fmt.Sprintf("stateDisks: %v", stateDisks))
@@ -484,6 +682,25 @@ func FormNetworkInterfaceConfig(nics []ct.VirtualMachineNetworkInterfaceModel) [
 	return n
 }
 
+func FormPCIDeviceConfig(devices []ct.VirtualMachinePCIDeviceModel) []service.ConfigureVirtualPciDeviceOptions {
+	p := make([]service.ConfigureVirtualPciDeviceOptions, len(devices))
+	for i, v := range devices {
+		position, _ := strconv.Atoi(strings.TrimPrefix(v.Name.ValueString(), "hostpci"))
+		pconfig := service.ConfigureVirtualPciDeviceOptions{
+			DeviceName: utils.OptionalToPointerString(v.Name.ValueString()),
+			DeviceId:   utils.OptionalToPointerString(v.ID.ValueString()),
+			PCIe:       v.PCIE.ValueBool(),
+			Mdev:       utils.OptionalToPointerString(v.MDEV.ValueString()),
+			ROMBAR:     v.ROMBAR.ValueBool(),
+			ROMFile:    utils.OptionalToPointerString(v.ROMFile.ValueString()),
+			PrimaryGPU: v.PrimaryGPU.ValueBool(),
+			Position:   position,
+		}
+		p[i] = pconfig
+	}
+	return p
+}
+
 func FormDiskConfig(ctx context.Context, disks []ct.VirtualMachineDiskModel, new bool) []service.ConfigureVirtualMachineDiskOptions {
 	tflog.Debug(ctx, "Entered form disk config")
 	d := make([]service.ConfigureVirtualMachineDiskOptions, len(disks))
@@ -537,6 +754,114 @@ func FormKeyboardConfig(key basetypes.StringValue) *proxmox.VirtualMachineKeyboa
 	return &k
 }
 
+func FormHotplugConfig(hotplug types.Set) []string {
+	features := utils.SetTypeToStringSlice(hotplug)
+	if features == nil {
+		return []string{}
+	}
+	return features
+}
+
+func efiDisksAreSame(state *ct.VirtualMachineEfiDiskModel, plan *ct.VirtualMachineEfiDiskModel) bool {
+	if state == nil || plan == nil {
+		return state == plan
+	}
+	return state.Storage.Equal(plan.Storage) && state.Type.Equal(plan.Type) && state.PreEnrolledKeys.Equal(plan.PreEnrolledKeys)
+}
+
+func tpmStatesAreSame(state *ct.VirtualMachineTpmStateModel, plan *ct.VirtualMachineTpmStateModel) bool {
+	if state == nil || plan == nil {
+		return state == plan
+	}
+	return state.Storage.Equal(plan.Storage) && state.Version.Equal(plan.Version)
+}
+
+func FormEfiDiskConfig(disk *ct.VirtualMachineEfiDiskModel) *service.ConfigureVirtualMachineEfiDiskOptions {
+	return &service.ConfigureVirtualMachineEfiDiskOptions{
+		Storage:         disk.Storage.ValueString(),
+		EfiType:         disk.Type.ValueString(),
+		PreEnrolledKeys: disk.PreEnrolledKeys.ValueBool(),
+	}
+}
+
+func FormTpmStateConfig(tpm *ct.VirtualMachineTpmStateModel) *service.ConfigureVirtualMachineTpmStateOptions {
+	return &service.ConfigureVirtualMachineTpmStateOptions{
+		Storage: tpm.Storage.ValueString(),
+		Version: tpm.Version.ValueString(),
+	}
+}
+
+func FormSerialDeviceConfig(devices []ct.VirtualMachineSerialDeviceModel) []service.ConfigureVirtualMachineSerialDeviceOptions {
+	options := []service.ConfigureVirtualMachineSerialDeviceOptions{}
+	for _, d := range devices {
+		options = append(options, service.ConfigureVirtualMachineSerialDeviceOptions{
+			Position: int(d.Position.ValueInt64()),
+			Type:     d.Type.ValueString(),
+		})
+	}
+	return options
+}
+
+func FormUSBDeviceConfig(devices []ct.VirtualMachineUSBDeviceModel) []service.ConfigureVirtualMachineUSBDeviceOptions {
+	options := []service.ConfigureVirtualMachineUSBDeviceOptions{}
+	for _, d := range devices {
+		o := service.ConfigureVirtualMachineUSBDeviceOptions{
+			Position: int(d.Position.ValueInt64()),
+			Mapping:  utils.OptionalToPointerString(d.Mapping.ValueString()),
+			USB3:     d.USB3.ValueBool(),
+		}
+		switch {
+		case !d.HostId.IsNull():
+			o.Host = utils.OptionalToPointerString(d.HostId.ValueString())
+		case !d.HostPort.IsNull():
+			o.Host = utils.OptionalToPointerString(d.HostPort.ValueString())
+		case d.Spice.ValueBool():
+			host := vm.UsbHostSpice
+			o.Host = &host
+		}
+		options = append(options, o)
+	}
+	return options
+}
+
+func hasSpiceEnhancements(display *ct.VirtualMachineDisplayModel) bool {
+	return display.SpiceFolderSharing.ValueBool() || !display.SpiceVideoStreaming.IsNull()
+}
+
+func FormDisplayConfig(display *ct.VirtualMachineDisplayModel) *service.ConfigureVirtualMachineDisplayOptions {
+	d := service.ConfigureVirtualMachineDisplayOptions{
+		Type:                display.Type.ValueString(),
+		SpiceFolderSharing:  display.SpiceFolderSharing.ValueBool(),
+		SpiceVideoStreaming: utils.OptionalToPointerString(display.SpiceVideoStreaming.ValueString()),
+	}
+	if !display.Memory.IsNull() {
+		d.Memory = utils.OptionaInt64ToPointerInt(display.Memory.ValueInt64())
+	}
+	return &d
+}
+
+func FormRngConfig(rng *ct.VirtualMachineRngModel) *service.ConfigureVirtualMachineRngOptions {
+	return &service.ConfigureVirtualMachineRngOptions{
+		Source:   rng.Source.ValueString(),
+		MaxBytes: rng.MaxBytes.ValueInt64(),
+		Period:   rng.Period.ValueInt64(),
+	}
+}
+
+func FormWatchdogConfig(watchdog *ct.VirtualMachineWatchdogModel) *service.ConfigureVirtualMachineWatchdogOptions {
+	return &service.ConfigureVirtualMachineWatchdogOptions{
+		Model:  watchdog.Model.ValueString(),
+		Action: utils.OptionalToPointerString(watchdog.Action.ValueString()),
+	}
+}
+
+func FormAudioConfig(audio *ct.VirtualMachineAudioModel) *service.ConfigureVirtualMachineAudioOptions {
+	return &service.ConfigureVirtualMachineAudioOptions{
+		Device: audio.Device.ValueString(),
+		Driver: audio.Driver.ValueString(),
+	}
+}
+
 func FormAgentConfig(agent *ct.VirtualMachineAgentModel) *service.ConfigureVirtualMachineAgentOptions {
 	if agent == nil {
 		return nil
@@ -559,6 +884,25 @@ func FormCPUConfig(cpu *ct.VirtualMachineCpuModel) *service.ConfigureVirtualMach
 		Sockets:      utils.OptionaInt64ToPointerInt(cpu.Sockets.ValueInt64()),
 		EmulatedType: utils.OptionalToPointerString(cpu.EmulatedType.ValueString()),
 		CpuUnits:     utils.OptionaToPointerInt64(cpu.CPUUnits.ValueInt64()),
+		VCpus:        utils.OptionaInt64ToPointerInt(cpu.VCPUs.ValueInt64()),
+		Affinity:     utils.OptionalToPointerString(cpu.Affinity.ValueString()),
+		Flags:        utils.SetTypeToStringSlice(cpu.Flags),
+		Hidden:       cpu.Hidden.ValueBool(),
+		HvVendorId:   utils.OptionalToPointerString(cpu.HvVendorId.ValueString()),
+		Numa:         cpu.Numa.ValueBool(),
+	}
+	if !cpu.CPULimit.IsNull() && !cpu.CPULimit.IsUnknown() {
+		limit := cpu.CPULimit.ValueFloat64()
+		c.Limit = &limit
+	}
+	for i, n := range cpu.NumaNodes {
+		c.NumaNodes = append(c.NumaNodes, service.ConfigureVirtualMachineNumaNodeOptions{
+			Position:  i,
+			Cpus:      n.Cpus.ValueString(),
+			Memory:    utils.OptionaToPointerInt64(n.Memory.ValueInt64()),
+			HostNodes: utils.OptionalToPointerString(n.HostNodes.ValueString()),
+			Policy:    utils.OptionalToPointerString(n.Policy.ValueString()),
+		})
 	}
 	return &c
 }
@@ -572,12 +916,13 @@ func FormMemoryConfig(mem *ct.VirtualMachineMemoryModel) *service.ConfigureVirtu
 		Dedicated: utils.OptionaToPointerInt64(mem.Dedicated.ValueInt64()),
 		Shared:    utils.OptionaToPointerInt64(mem.Shared.ValueInt64()),
 		Floating:  utils.OptionaToPointerInt64(mem.Floating.ValueInt64()),
+		Hugepages: utils.OptionalToPointerString(mem.Hugepages.ValueString()),
 	}
 
 	return &m
 }
 
-func FormCloudInitConfig(ctx context.Context, ci *ct.VirtualMachineCloudInitModel) *service.ConfigureVirtualMachineCloudInitOptions {
+func FormCloudInitConfig(ctx context.Context, vmId int, ci *ct.VirtualMachineCloudInitModel) *service.ConfigureVirtualMachineCloudInitOptions {
 	tflog.Debug(ctx, fmt.Sprintf("Cloud Init: %v", ci))
 	if ci != nil {
 		c := service.ConfigureVirtualMachineCloudInitOptions{}
@@ -624,6 +969,10 @@ func FormCloudInitConfig(ctx context.Context, ci *ct.VirtualMachineCloudInitMode
 			c.Dns = &dns
 		}
 
+		if ci.Custom != nil {
+			c.Custom = FormCloudInitCustomConfig(vmId, ci.Custom)
+		}
+
 		return &c
 	}
 	tflog.Debug(ctx, "Cloud Init is nil")
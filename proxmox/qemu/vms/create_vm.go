@@ -3,15 +3,57 @@ package vms
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/errors"
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const idAllocationAttempts = 5
+
+func (r *virtualMachineResource) createVm(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	if !plan.ID.IsNull() && !plan.ID.IsUnknown() {
+		return r.routeCreateVm(ctx, plan)
+	}
+
+	tflog.Debug(ctx, "no id set, allocating virtual machine id")
+	input := &service.ReserveVirtualMachineIdInput{}
+	if plan.IDRange != nil {
+		input.Start = utils.OptionaInt64ToPointerInt(plan.IDRange.Start.ValueInt64())
+		input.End = utils.OptionaInt64ToPointerInt(plan.IDRange.End.ValueInt64())
+	}
+
+	var err error
+	for attempt := 1; attempt <= idAllocationAttempts; attempt++ {
+		vmId, rerr := r.client.ReserveVirtualMachineId(ctx, input)
+		if rerr != nil {
+			return rerr
+		}
+		tflog.Debug(ctx, fmt.Sprintf("reserved virtual machine id %v", vmId))
+		plan.ID = types.Int64Value(int64(vmId))
+
+		err = r.routeCreateVm(ctx, plan)
+		r.client.ReleaseVirtualMachineId(vmId)
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		tflog.Debug(ctx, fmt.Sprintf("virtual machine id %v was taken, retrying (%v/%v)", vmId, attempt, idAllocationAttempts))
+	}
+
+	return err
+}
+
 func (r *virtualMachineResource) routeCreateVm(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
 	tflog.Debug(ctx, "route virtual machine creation method")
 	switch true {
@@ -19,6 +61,12 @@ func (r *virtualMachineResource) routeCreateVm(ctx context.Context, plan *vt.Vir
 		return r.clone(ctx, plan)
 	case plan.ISO != nil:
 		return r.iso(ctx, plan)
+	case plan.Restore != nil:
+		return r.restore(ctx, plan)
+	case plan.CloudImage != nil:
+		return r.cloudImage(ctx, plan)
+	case plan.PXE != nil:
+		return r.pxe(ctx, plan)
 	default:
 		tflog.Debug(ctx, "No valid init options provided")
 		return fmt.Errorf("no valid init options provided")
@@ -47,7 +95,7 @@ func (r *virtualMachineResource) clone(ctx context.Context, plan *vt.VirtualMach
 	}
 
 	// wait till clone is complete
-	err = r.waitForLock(ctx, node, vmId, r.timeouts.Clone)
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Clone)
 	if err != nil {
 		tflog.Error(ctx, "clone recieved error: "+err.Error())
 		return err
@@ -60,9 +108,12 @@ func (r *virtualMachineResource) clone(ctx context.Context, plan *vt.VirtualMach
 func (r *virtualMachineResource) iso(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
 	tflog.Debug(ctx, "iso virtual machine creation method")
 
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
 	err := r.client.CreateVirtualMachineIso(ctx, &service.CreateVirtualMachineIsoInput{
-		Node:         plan.Node.ValueString(),
-		VmId:         int(plan.ID.ValueInt64()),
+		Node:         node,
+		VmId:         vmId,
 		IsoStorage:   plan.ISO.Storage.ValueString(),
 		IsoImage:     plan.ISO.Image.ValueString(),
 		Name:         utils.OptionalToPointerString(plan.Name.ValueString()),
@@ -70,27 +121,324 @@ func (r *virtualMachineResource) iso(ctx context.Context, plan *vt.VirtualMachin
 		ResourcePool: utils.OptionalToPointerString(plan.ResourcePool.ValueString()),
 	})
 	if err != nil {
-		return nil
+		tflog.Error(ctx, "iso recieved error: "+err.Error())
+		return err
+	}
+
+	// wait till the vm is created
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "iso recieved error: "+err.Error())
+		return err
 	}
 
+	tflog.Debug(ctx, "iso virtual machine complete")
 	return nil
 }
 
-func (r *virtualMachineResource) waitForLock(ctx context.Context, node string, vmId int, timeout int64) error {
-	tflog.Debug(ctx, "waiting lock to release...")
+func (r *virtualMachineResource) restore(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	tflog.Debug(ctx, "restore virtual machine creation method")
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
+	input := &service.RestoreVirtualMachineInput{
+		Node:         node,
+		VmId:         vmId,
+		Archive:      plan.Restore.Archive.ValueString(),
+		Storage:      utils.OptionalToPointerString(plan.Restore.Storage.ValueString()),
+		Unique:       plan.Restore.Unique.ValueBool(),
+		ResourcePool: utils.OptionalToPointerString(plan.ResourcePool.ValueString()),
+	}
+	if !plan.Restore.BandwidthLimit.IsNull() {
+		bwlimit := plan.Restore.BandwidthLimit.ValueInt64()
+		input.BandwidthLimit = &bwlimit
+	}
+
+	upid, err := r.client.RestoreVirtualMachine(ctx, input)
+	if err != nil {
+		tflog.Error(ctx, "restore recieved error: "+err.Error())
+		return err
+	}
+
+	// wait till the archive is restored
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "restore recieved error: "+err.Error())
+		return err
+	}
+
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "restore recieved error: "+err.Error())
+		return err
+	}
+
+	tflog.Debug(ctx, "restore virtual machine complete")
+	return nil
+}
+
+// proxmox only accepts images with an iso or img extension as iso content
+func cloudImageFileName(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return "", fmt.Errorf("unable to determine file name from url %s, set file_name", rawUrl)
+	}
+	if !strings.HasSuffix(name, ".img") && !strings.HasSuffix(name, ".iso") {
+		name = name + ".img"
+	}
+	return name, nil
+}
+
+// virtual machines created in parallel from the same image share the download, an image verified
+// against its checksum is not replaced again while other virtual machines may be importing it
+type cloudImageDownloads struct {
+	mu       sync.Mutex
+	volumes  map[string]*sync.Mutex
+	verified map[string]bool
+}
+
+var downloads = &cloudImageDownloads{
+	volumes:  map[string]*sync.Mutex{},
+	verified: map[string]bool{},
+}
+
+func (d *cloudImageDownloads) lock(key string) func() {
+	d.mu.Lock()
+	l, ok := d.volumes[key]
+	if !ok {
+		l = &sync.Mutex{}
+		d.volumes[key] = l
+	}
+	d.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+func (r *virtualMachineResource) downloadCloudImage(ctx context.Context, node string, image *vt.VirtualMachineCloudImageModel) (string, error) {
+	storage := image.DownloadStorage.ValueString()
+	fileName := image.FileName.ValueString()
+	if image.FileName.IsNull() {
+		name, err := cloudImageFileName(image.Url.ValueString())
+		if err != nil {
+			return "", err
+		}
+		fileName = name
+	}
+	volume := fmt.Sprintf("%s:iso/%s", storage, fileName)
+
+	// local storages hold a separate copy on every node
+	key := node + "/" + volume
+	unlock := downloads.lock(key)
+	defer unlock()
+	if downloads.verified[key] {
+		tflog.Debug(ctx, fmt.Sprintf("cloud image %s already downloaded and verified", volume))
+		return volume, nil
+	}
+
+	exists, err := r.client.StorageVolumeExists(ctx, node, storage, "iso", volume)
+	if err != nil {
+		return "", err
+	}
+	if exists && image.Checksum.IsNull() {
+		tflog.Debug(ctx, fmt.Sprintf("cloud image %s already downloaded", volume))
+		return volume, nil
+	}
+
+	// the existing file can't be verified against the checksum, so it is replaced by a verified download
+	if exists {
+		tflog.Debug(ctx, fmt.Sprintf("removing cloud image %s to download and verify it again", volume))
+		upid, err := r.client.DeleteStorageVolume(ctx, node, storage, volume)
+		if err != nil {
+			return "", err
+		}
+		if upid != nil {
+			err = r.client.WaitForTask(ctx, node, *upid, r.timeouts.Create)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	upid, err := r.client.DownloadCloudImage(ctx, &service.DownloadCloudImageInput{
+		Node:              node,
+		Storage:           storage,
+		Url:               image.Url.ValueString(),
+		FileName:          fileName,
+		Checksum:          utils.OptionalToPointerString(image.Checksum.ValueString()),
+		ChecksumAlgorithm: utils.OptionalToPointerString(image.ChecksumAlgorithm.ValueString()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	tflog.Debug(ctx, "waiting for download task "+upid)
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Create)
+	if err != nil {
+		return "", err
+	}
+
+	if !image.Checksum.IsNull() {
+		downloads.verified[key] = true
+	}
+	return volume, nil
+}
+
+func (r *virtualMachineResource) cloudImage(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	tflog.Debug(ctx, "cloud image virtual machine creation method")
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
+	source := plan.CloudImage.Volume.ValueString()
+	if plan.CloudImage.Volume.IsNull() {
+		volume, err := r.downloadCloudImage(ctx, node, plan.CloudImage)
+		if err != nil {
+			tflog.Error(ctx, "cloud image download recieved error: "+err.Error())
+			return err
+		}
+		source = volume
+	}
+
+	upid, err := r.client.CreateVirtualMachineFromImage(ctx, &service.CreateVirtualMachineFromImageInput{
+		Node:         node,
+		VmId:         vmId,
+		Disk:         plan.CloudImage.Disk.ValueString(),
+		Storage:      plan.CloudImage.Storage.ValueString(),
+		Source:       source,
+		Name:         utils.OptionalToPointerString(plan.Name.ValueString()),
+		Description:  utils.OptionalToPointerString(plan.Description.ValueString()),
+		ResourcePool: utils.OptionalToPointerString(plan.ResourcePool.ValueString()),
+	})
+	if err != nil {
+		tflog.Error(ctx, "cloud image recieved error: "+err.Error())
+		return err
+	}
+
+	// wait till the image is imported
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "cloud image recieved error: "+err.Error())
+		return err
+	}
+
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "cloud image recieved error: "+err.Error())
+		return err
+	}
+
+	tflog.Debug(ctx, "cloud image virtual machine complete")
+	return nil
+}
+
+func pxeBootDevice(plan *vt.VirtualMachineResourceModel) string {
+	return fmt.Sprintf("net%v", plan.PXE.NetworkInterface.ValueInt64())
+}
+
+func (r *virtualMachineResource) pxe(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	tflog.Debug(ctx, "pxe virtual machine creation method")
+
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
+	// disks and network interfaces are added when the virtual machine is configured
+	err := r.client.CreateVirtualMachinePxe(ctx, &service.CreateVirtualMachinePxeInput{
+		Node:         node,
+		VmId:         vmId,
+		BootDevice:   pxeBootDevice(plan),
+		Name:         utils.OptionalToPointerString(plan.Name.ValueString()),
+		Description:  utils.OptionalToPointerString(plan.Description.ValueString()),
+		ResourcePool: utils.OptionalToPointerString(plan.ResourcePool.ValueString()),
+	})
+	if err != nil {
+		tflog.Error(ctx, "pxe recieved error: "+err.Error())
+		return err
+	}
+
+	err = r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Create)
+	if err != nil {
+		tflog.Error(ctx, "pxe recieved error: "+err.Error())
+		return err
+	}
+
+	tflog.Debug(ctx, "pxe virtual machine complete")
+	return nil
+}
+
+func (r *virtualMachineResource) waitForAgent(ctx context.Context, node string, vmId int, timeout int64) error {
+	tflog.Debug(ctx, "waiting for guest agent...")
 	deadline := setDeadline(timeout)
 	for {
-		status, err := r.client.GetVirtualMachineStatus(ctx, node, vmId)
+		err := r.client.PingVirtualMachineAgent(ctx, node, vmId)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for guest agent: %w", err)
+		}
+		tflog.Debug(ctx, "guest agent is not responding, waiting 10 seconds...")
+		time.Sleep(10 * time.Second)
+	}
+	return nil
+}
+
+// blocks until the guest agent reports an address matching wait_for_ip
+func (r *virtualMachineResource) waitForIp(ctx context.Context, node string, vmId int, wait *vt.VirtualMachineWaitForIpModel) error {
+	var cidr *net.IPNet
+	if !wait.CIDR.IsNull() {
+		_, n, err := net.ParseCIDR(wait.CIDR.ValueString())
 		if err != nil {
-			tflog.Error(ctx, "error: "+err.Error())
-			if time.Now().After(deadline) {
-				return fmt.Errorf("timeout waiting for lock to release")
+			return err
+		}
+		cidr = n
+	}
+
+	tflog.Debug(ctx, "waiting for guest ip address...")
+	deadline := setDeadline(wait.Timeout.ValueInt64())
+	for {
+		guest, err := r.client.DescribeVirtualMachineGuest(ctx, node, vmId)
+		if err == nil {
+			address := guest.FindAddress(wait.Interface.ValueString(), cidr)
+			if address != nil {
+				tflog.Debug(ctx, fmt.Sprintf("guest reported ip address %s", *address))
+				return nil
 			}
-		} else if !status.HasLock() {
-			break
 		}
-		tflog.Debug(ctx, "lock is still active, waiting 5 seconds...")
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for the guest agent to report an ip address")
+		}
+		tflog.Debug(ctx, "guest has no matching ip address, waiting 5 seconds...")
 		time.Sleep(5 * time.Second)
 	}
-	return nil
+}
+
+// once the installed system is up the disks are placed ahead of the network interface
+func (r *virtualMachineResource) revertPxeBootOrder(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
+	err := r.waitForAgent(ctx, node, vmId, plan.PXE.FirstBootTimeout.ValueInt64())
+	if err != nil {
+		return err
+	}
+
+	devices := []string{}
+	if !plan.BootOrder.IsNull() && !plan.BootOrder.IsUnknown() {
+		devices = utils.ListTypeToStringSlice(plan.BootOrder)
+	} else {
+		for _, d := range plan.Disks.Disks {
+			devices = append(devices, fmt.Sprintf("%s%v", d.InterfaceType.ValueString(), d.Position.ValueInt64()))
+		}
+		sort.Strings(devices)
+		devices = append(devices, pxeBootDevice(plan))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("setting boot order to %v", devices))
+	return r.client.SetVirtualMachineBootOrder(ctx, node, vmId, devices)
 }
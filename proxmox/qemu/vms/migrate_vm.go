@@ -0,0 +1,122 @@
+package vms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	ct "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func isNodeChanged(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) bool {
+	return state.Node.ValueString() != plan.Node.ValueString()
+}
+
+func formMigrateRequest(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, online bool) *service.MigrateVirtualMachineInput {
+	request := &service.MigrateVirtualMachineInput{
+		Node:   state.Node.ValueString(),
+		VmId:   int(state.ID.ValueInt64()),
+		Target: plan.Node.ValueString(),
+		Online: online,
+	}
+
+	if plan.Migration == nil {
+		return request
+	}
+
+	request.WithLocalDisks = plan.Migration.WithLocalDisks.ValueBool()
+	if !plan.Migration.TargetStorage.IsNull() {
+		request.TargetStorage = utils.OptionalToPointerString(plan.Migration.TargetStorage.ValueString())
+	}
+	if !plan.Migration.StorageMapping.IsNull() {
+		mapping := map[string]string{}
+		for source, target := range plan.Migration.StorageMapping.Elements() {
+			mapping[source] = target.(types.String).ValueString()
+		}
+		request.StorageMapping = mapping
+	}
+
+	tflog.Debug(ctx, "migrate virtual machine request: "+utils.MarshalSafe(request))
+	return request
+}
+
+func (r *virtualMachineResource) migrateVm(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) error {
+	node := state.Node.ValueString()
+	vmId := int(state.ID.ValueInt64())
+
+	running, err := r.isRunning(ctx, node, vmId)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Migrating virtual machine from '%s' to '%s', online: %v", node, plan.Node.ValueString(), running))
+	upid, err := r.client.MigrateVirtualMachine(ctx, formMigrateRequest(ctx, state, plan, running))
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "waiting for migration task "+upid)
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Migrate)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "waiting for lock")
+	return r.client.WaitForVirtualMachineLock(ctx, plan.Node.ValueString(), vmId, r.timeouts.Migrate)
+}
+
+func migrationValidator(ctx context.Context, client *service.Proxmox, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel, running bool, resp *resource.ModifyPlanResponse) {
+	if !isNodeChanged(state, plan) || !running {
+		return
+	}
+
+	if len(state.PCIDevices.PCIDevices) > 0 || len(state.ComputedPCIDevices.PCIDevices) > 0 {
+		resp.Diagnostics.AddWarning("PCI devices block online migration", fmt.Sprintf("The virtual machine has PCI devices passed through and cannot be migrated to %s while running. Stop it before applying this change.", plan.Node.ValueString()))
+	}
+
+	hostUSBDevices := []string{}
+	for _, d := range state.USBDevices {
+		if isHostUSBDevice(d) {
+			hostUSBDevices = append(hostUSBDevices, fmt.Sprintf("usb%v", d.Position.ValueInt64()))
+		}
+	}
+	if len(hostUSBDevices) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "USB devices block online migration", fmt.Sprintf("USB device(s) %v are attached from the host and the virtual machine cannot be migrated to %s while running. Stop it or remove the devices before applying this change.", hostUSBDevices, plan.Node.ValueString()))
+	}
+
+	if plan.Migration != nil && plan.Migration.WithLocalDisks.ValueBool() {
+		return
+	}
+
+	localStorage, err := client.ListNodeLocalStorage(ctx, state.Node.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "unable to list local storage for migration check: "+err.Error())
+		return
+	}
+
+	localDisks := []string{}
+	disks := []ct.VirtualMachineDiskModel{}
+	disks = append(disks, state.Disks.Disks...)
+	disks = append(disks, state.ComputedDisks.Disks...)
+	for _, d := range disks {
+		if utils.ListContains(localStorage, d.Storage.ValueString()) {
+			localDisks = append(localDisks, fmt.Sprintf("%s%v", d.InterfaceType.ValueString(), d.Position.ValueInt64()))
+		}
+	}
+	if state.EfiDisk != nil && utils.ListContains(localStorage, state.EfiDisk.Storage.ValueString()) {
+		localDisks = append(localDisks, vm.DiskInterfaceEfi+"0")
+	}
+	if state.TpmState != nil && utils.ListContains(localStorage, state.TpmState.Storage.ValueString()) {
+		localDisks = append(localDisks, vm.DiskInterfaceTpm+"0")
+	}
+	if len(localDisks) > 0 {
+		resp.Diagnostics.AddWarning("Local disks block online migration", fmt.Sprintf("Disk(s) %v are on local storage and cannot be migrated to %s while the virtual machine is running. Set `migration.with_local_disks` to migrate them.", localDisks, plan.Node.ValueString()))
+	}
+}
@@ -3,11 +3,13 @@ package vms
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/awlsring/proxmox-go/proxmox"
 	"github.com/awlsring/terraform-provider-proxmox/internal/service"
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/schemas"
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -15,9 +17,10 @@ import (
 )
 
 var (
-	_ resource.Resource               = &virtualMachineResource{}
-	_ resource.ResourceWithConfigure  = &virtualMachineResource{}
-	_ resource.ResourceWithModifyPlan = &virtualMachineResource{}
+	_ resource.Resource                = &virtualMachineResource{}
+	_ resource.ResourceWithConfigure   = &virtualMachineResource{}
+	_ resource.ResourceWithModifyPlan  = &virtualMachineResource{}
+	_ resource.ResourceWithImportState = &virtualMachineResource{}
 )
 
 func Resource() resource.Resource {
@@ -43,13 +46,22 @@ func (r *virtualMachineResource) Metadata(_ context.Context, req resource.Metada
 
 func (r *virtualMachineResource) createPlanModifiers(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *vt.VirtualMachineResourceModel) {
 	authCreateValidator(ctx, r.client.IsRoot, plan, resp)
+	idRangeValidator(ctx, plan, resp)
+	pxeValidator(ctx, plan, resp)
+	waitForIpValidator(ctx, plan, resp)
+	bootOrderValidator(ctx, nil, plan, resp)
+	efiDiskValidator(ctx, plan, resp)
+	displayValidator(ctx, plan, resp)
+	cpuValidator(ctx, plan, resp)
+	usbDevicesValidator(ctx, plan, resp)
+	templateValidator(ctx, plan, resp)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
 func (r *virtualMachineResource) updatePlanModifiers(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
-	node := plan.Node.ValueString()
+	node := state.Node.ValueString()
 	vmId := int(state.ID.ValueInt64())
 
 	status, err := r.client.GetVirtualMachineStatus(ctx, node, vmId)
@@ -60,15 +72,24 @@ func (r *virtualMachineResource) updatePlanModifiers(ctx context.Context, req re
 		)
 		return
 	}
-	if status.Status != proxmox.VIRTUALMACHINESTATUS_RUNNING {
+	if status.Status == proxmox.VIRTUALMACHINESTATUS_RUNNING {
 		powerOffValidator(ctx, r.client, state, plan, resp)
 	}
+	migrationValidator(ctx, r.client, state, plan, status.Status == proxmox.VIRTUALMACHINESTATUS_RUNNING, resp)
 
 	authUpdateValidator(ctx, r.client.IsRoot, plan, resp)
 
 	changeValidatorDiskSize(ctx, state, plan, resp)
 	changeValidatorDiskStorage(ctx, state, plan, resp)
 	changeValidatorDiskRemoved(ctx, state, plan, resp)
+	bootOrderValidator(ctx, state, plan, resp)
+	changeValidatorFirmwareDisks(ctx, state, plan, resp)
+	efiDiskValidator(ctx, plan, resp)
+	displayValidator(ctx, plan, resp)
+	cpuValidator(ctx, plan, resp)
+	usbDevicesValidator(ctx, plan, resp)
+	waitForIpValidator(ctx, plan, resp)
+	templateValidator(ctx, plan, resp)
 	// carry over computed values sets to prevent unnecessary diffs
 	amended := plan
 	amended.ComputedDisks = state.ComputedDisks
@@ -137,7 +158,7 @@ func (r *virtualMachineResource) Create(ctx context.Context, req resource.Create
 
 	// create
 	tflog.Debug(ctx, "Creating virtual machine")
-	err := r.routeCreateVm(ctx, &plan)
+	err := r.createVm(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating virtual machine",
@@ -160,7 +181,12 @@ func (r *virtualMachineResource) Create(ctx context.Context, req resource.Create
 
 	// configure
 	tflog.Debug(ctx, "Configuring virtual machine")
-	err = r.determineVmConfigurations(ctx, currentModel, &plan)
+	configurePlan := plan
+	if plan.PXE != nil && plan.PXE.RevertBootOrder.ValueBool() {
+		// keep booting from the network until the installed system is up
+		configurePlan.BootOrder = types.ListNull(types.StringType)
+	}
+	err = r.determineVmConfigurations(ctx, currentModel, &configurePlan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error configuring virtual machine",
@@ -180,8 +206,30 @@ func (r *virtualMachineResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	// convert
+	if plan.Template.ValueBool() {
+		tflog.Debug(ctx, "Converting virtual machine to template")
+		err = r.convertToTemplate(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error converting virtual machine to template",
+				"Could not convert virtual machine to template, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		m, err = r.readModelWithContext(ctx, node, vmId, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading virtual machine",
+				"Could not read virtual machine, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// launch
-	if plan.StartOnCreate.ValueBool() {
+	if plan.StartOnCreate.ValueBool() && !plan.Template.ValueBool() {
 		tflog.Debug(ctx, "Starting virtual machine")
 		err = r.client.StartVirtualMachine(ctx, plan.Node.ValueString(), int(plan.ID.ValueInt64()))
 		if err != nil {
@@ -191,6 +239,38 @@ func (r *virtualMachineResource) Create(ctx context.Context, req resource.Create
 			)
 			return
 		}
+
+		if plan.PXE != nil && plan.PXE.RevertBootOrder.ValueBool() {
+			tflog.Debug(ctx, "Waiting for first boot to revert boot order")
+			err = r.revertPxeBootOrder(ctx, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reverting boot order",
+					"Could not boot virtual machine from disk after network install, unexpected error: "+err.Error(),
+				)
+				return
+			}
+
+			m, err = r.readModelWithContext(ctx, plan.Node.ValueString(), int(plan.ID.ValueInt64()), &plan)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading virtual machine",
+					"Could not read virtual machine, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
+
+		if plan.WaitForIP != nil {
+			m, err = r.waitForIpAndRead(ctx, node, vmId, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error waiting for virtual machine ip address",
+					"Could not read the guest ip address, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
 	}
 
 	diags = resp.State.Set(ctx, &m)
@@ -207,6 +287,11 @@ func (r *virtualMachineResource) readModelWithContext(ctx context.Context, node
 	}
 	model := vt.VMToResourceModel(ctx, vm, state)
 
+	err = r.readCloudInitSnippets(ctx, node, id, model, state)
+	if err != nil {
+		return nil, err
+	}
+
 	if !state.ResourcePool.IsNull() {
 		tflog.Debug(ctx, "Determining resource pool")
 		in, pool, err := r.client.DetermineVirtualMachineResourcePool(ctx, id)
@@ -221,14 +306,30 @@ func (r *virtualMachineResource) readModelWithContext(ctx context.Context, node
 	}
 
 	// pass resource tf metadata
+	model.IDRange = state.IDRange
 	model.Clone = state.Clone
 	model.ISO = state.ISO
+	model.Restore = state.Restore
+	model.CloudImage = state.CloudImage
+	model.PXE = state.PXE
 	model.Timeouts = state.Timeouts
 	model.StartOnCreate = state.StartOnCreate
+	model.StopStrategy = state.StopStrategy
+	model.RebootOnPendingChanges = state.RebootOnPendingChanges
+	model.Migration = state.Migration
+	model.WaitForIP = state.WaitForIP
 
 	return model, nil
 }
 
+func (r *virtualMachineResource) waitForIpAndRead(ctx context.Context, node string, vmId int, plan *vt.VirtualMachineResourceModel) (*vt.VirtualMachineResourceModel, error) {
+	err := r.waitForIp(ctx, node, vmId, plan.WaitForIP)
+	if err != nil {
+		return nil, err
+	}
+	return r.readModelWithContext(ctx, node, vmId, plan)
+}
+
 func (r *virtualMachineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Debug(ctx, "Read virtual machine method")
 	var state vt.VirtualMachineResourceModel
@@ -276,6 +377,28 @@ func (r *virtualMachineResource) Update(ctx context.Context, req resource.Update
 	vmId := int(state.ID.ValueInt64())
 	r.timeouts = loadTimeouts(ctx, plan.Timeouts)
 
+	if isNodeChanged(&state, &plan) {
+		err := r.migrateVm(ctx, &state, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error migrating virtual machine",
+				"Could not migrate virtual machine, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		// compare the plan against the migrated virtual machine, disks may have moved storage
+		migrated, err := r.readModelWithContext(ctx, node, vmId, &state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading virtual machine",
+				"Could not read migrated virtual machine, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		state = *migrated
+	}
+
 	stopped, err := r.stopIfSensitivePropertyChanged(ctx, &state, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -299,6 +422,30 @@ func (r *virtualMachineResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	if !stopped && !isTemplateConversion(&state, &plan) {
+		err = r.rebootIfPendingChanges(ctx, &state, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rebooting virtual machine",
+				"Could not reboot virtual machine to apply pending changes, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if isTemplateConversion(&state, &plan) {
+		err = r.convertToTemplate(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error converting virtual machine to template",
+				"Could not convert virtual machine to template, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		// templates are never started again
+		stopped = false
+	}
+
 	err = r.modifyResourcePool(ctx, vmId, state.ResourcePool, plan.ResourcePool)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -329,6 +476,28 @@ func (r *virtualMachineResource) Update(ctx context.Context, req resource.Update
 		}
 	}
 
+	if plan.WaitForIP != nil {
+		running, err := r.isRunning(ctx, node, vmId)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting virtual machine state",
+				"Could not read virtual machine status, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		if running {
+			m, err = r.waitForIpAndRead(ctx, node, vmId, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error waiting for virtual machine ip address",
+					"Could not read the guest ip address, unexpected error: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
 	diags = resp.State.Set(ctx, &m)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -349,14 +518,25 @@ func (r *virtualMachineResource) Delete(ctx context.Context, req resource.Delete
 	vmId := int(state.ID.ValueInt64())
 	r.timeouts = loadTimeouts(ctx, state.Timeouts)
 
-	err := r.stopVm(ctx, node, vmId)
+	// templates are never running, stopped virtual machines are deleted as they are
+	running, err := r.isRunning(ctx, node, vmId)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error stopping virtual machine",
-			"Could not stop virtual machine, unexpected error: "+err.Error(),
+			"Error reading virtual machine",
+			"Could not read virtual machine, unexpected error: "+err.Error(),
 		)
 		return
 	}
+	if running {
+		err = r.stopVm(ctx, node, vmId, StopStrategy(state.StopStrategy.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error stopping virtual machine",
+				"Could not stop virtual machine, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting vm: '%s' '%v'", node, vmId))
 	err = r.deleteVm(ctx, node, vmId)
@@ -367,4 +547,63 @@ func (r *virtualMachineResource) Delete(ctx context.Context, req resource.Delete
 		)
 		return
 	}
+
+	r.deleteCloudInitSnippets(ctx, &state)
+}
+
+func (r *virtualMachineResource) importModel(ctx context.Context, node string, id int) (*vt.VirtualMachineResourceModel, error) {
+	vm, err := r.client.DescribeVirtualMachine(ctx, node, id)
+	if err != nil {
+		return nil, err
+	}
+	model := vt.VMToImportedResourceModel(ctx, vm)
+
+	tflog.Debug(ctx, "Determining resource pool")
+	in, pool, err := r.client.DetermineVirtualMachineResourcePool(ctx, id)
+	if err != nil {
+		tflog.Error(ctx, "Error determining resource pool:"+err.Error())
+		return nil, err
+	}
+	if in {
+		tflog.Debug(ctx, fmt.Sprintf("Resource pool '%v'", pool))
+		model.ResourcePool = types.StringValue(pool)
+	}
+
+	return model, nil
+}
+
+func (r *virtualMachineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, "Import virtual machine method")
+	node, id, err := utils.UnpackId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing virtual machine",
+			"Expected import identifier with format <node>/<vmid>, got: "+req.ID,
+		)
+		return
+	}
+
+	vmId, err := strconv.Atoi(id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing virtual machine",
+			"Could not parse virtual machine id, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	model, err := r.importModel(ctx, node, vmId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading virtual machine",
+			"Could not read virtual machine, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
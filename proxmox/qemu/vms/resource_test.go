@@ -0,0 +1,104 @@
+package vms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	qt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/schemas"
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// serves the status of vm 100 on the node it lives on, every other node answers like proxmox does for a missing vm
+func newTestResource(t *testing.T, node string, status string) *virtualMachineResource {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api2/json/nodes/"+node+"/qemu/100/status/current", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"status": status, "vmid": 100, "ha": map[string]interface{}{"managed": 0}},
+		})
+	})
+	mux.HandleFunc("/api2/json/nodes/"+node+"/storage", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"storage": "ceph", "type": "rbd", "content": "images", "shared": 1}},
+		})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"data":null}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := service.New(service.ClientConfig{Endpoint: server.URL, Token: "test@pve!test=token"})
+	assert.NoError(t, err)
+	return &virtualMachineResource{client: client, timeouts: loadTimeouts(context.Background(), nil)}
+}
+
+func newTestModel(node string) *vt.VirtualMachineResourceModel {
+	m := vt.VMToImportedResourceModel(context.Background(), &service.VirtualMachine{
+		Node: node,
+		VmId: 100,
+	})
+	m.Timeouts = nil
+	return m
+}
+
+func planNodeChange(t *testing.T, r *virtualMachineResource, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) *resource.ModifyPlanResponse {
+	resp := &resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{
+			Schema: schemas.ResourceSchema,
+			Raw:    tftypes.NewValue(schemas.ResourceSchema.Type().TerraformType(context.Background()), nil),
+		},
+	}
+	r.updatePlanModifiers(context.Background(), resource.ModifyPlanRequest{}, resp, state, plan)
+	return resp
+}
+
+func TestUpdatePlanModifiersNodeChange(t *testing.T) {
+	r := newTestResource(t, "pve1", "running")
+	state := newTestModel("pve1")
+	plan := newTestModel("pve1")
+	plan.Node = types.StringValue("pve2")
+
+	resp := planNodeChange(t, r, state, plan)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+}
+
+func TestUpdatePlanModifiersNodeChangeWithHostUSBDevice(t *testing.T) {
+	r := newTestResource(t, "pve1", "running")
+	device := qt.VirtualMachineUSBDeviceModel{
+		Position: types.Int64Value(0),
+		HostId:   types.StringValue("1a86:7523"),
+		HostPort: types.StringNull(),
+		Spice:    types.BoolNull(),
+		Mapping:  types.StringNull(),
+		USB3:     types.BoolValue(false),
+	}
+	state := newTestModel("pve1")
+	state.USBDevices = []qt.VirtualMachineUSBDeviceModel{device}
+	plan := newTestModel("pve1")
+	plan.USBDevices = []qt.VirtualMachineUSBDeviceModel{device}
+	plan.Node = types.StringValue("pve2")
+
+	resp := planNodeChange(t, r, state, plan)
+	assert.True(t, resp.Diagnostics.HasError())
+	found := false
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "USB devices block online migration" {
+			found = true
+		}
+	}
+	assert.True(t, found, "%v", resp.Diagnostics)
+}
@@ -0,0 +1,76 @@
+package schemas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const creationReplaceDescription = "Changing how the virtual machine was created replaces it. Setting it on an imported virtual machine does not."
+
+// the creation blocks can't be read back from proxmox, so they are null in the state of an imported virtual machine.
+// setting one afterwards only records how the virtual machine was created and shouldn't replace it.
+func creationBlockInState(ctx context.Context, state tfsdk.State, p path.Path) bool {
+	steps := p.Steps()
+	if len(steps) == 0 {
+		return false
+	}
+	name, ok := steps[0].(path.PathStepAttributeName)
+	if !ok {
+		return false
+	}
+
+	var block types.Object
+	diags := state.GetAttribute(ctx, path.Root(string(name)), &block)
+	if diags.HasError() {
+		return false
+	}
+	return !block.IsNull()
+}
+
+func creationObjectRequiresReplace() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = creationBlockInState(ctx, req.State, req.Path)
+		},
+		creationReplaceDescription,
+		creationReplaceDescription,
+	)
+}
+
+func creationStringRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = creationBlockInState(ctx, req.State, req.Path)
+		},
+		creationReplaceDescription,
+		creationReplaceDescription,
+	)
+}
+
+func creationInt64RequiresReplace() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = creationBlockInState(ctx, req.State, req.Path)
+		},
+		creationReplaceDescription,
+		creationReplaceDescription,
+	)
+}
+
+func creationBoolRequiresReplace() planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = creationBlockInState(ctx, req.State, req.Path)
+		},
+		creationReplaceDescription,
+		creationReplaceDescription,
+	)
+}
@@ -0,0 +1,44 @@
+package schemas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func stateWithClone(clone bool) tfsdk.State {
+	schemaType := ResourceSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range schemaType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	if clone {
+		cloneType := schemaType.AttributeTypes["clone"].(tftypes.Object)
+		values["clone"] = tftypes.NewValue(cloneType, map[string]tftypes.Value{
+			"storage":    tftypes.NewValue(tftypes.String, "local"),
+			"source":     tftypes.NewValue(tftypes.Number, 100),
+			"full_clone": tftypes.NewValue(tftypes.Bool, true),
+		})
+	}
+	return tfsdk.State{
+		Schema: ResourceSchema,
+		Raw:    tftypes.NewValue(schemaType, values),
+	}
+}
+
+func TestCreationBlockInState(t *testing.T) {
+	ctx := context.Background()
+
+	imported := stateWithClone(false)
+	assert.False(t, creationBlockInState(ctx, imported, path.Root("clone")))
+	assert.False(t, creationBlockInState(ctx, imported, path.Root("clone").AtName("source")))
+
+	created := stateWithClone(true)
+	assert.True(t, creationBlockInState(ctx, created, path.Root("clone")))
+	assert.True(t, creationBlockInState(ctx, created, path.Root("clone").AtName("source")))
+	assert.False(t, creationBlockInState(ctx, created, path.Root("iso").AtName("image")))
+}
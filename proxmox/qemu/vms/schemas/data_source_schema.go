@@ -84,6 +84,40 @@ var VirtualMachineDataSourceSchema = schema.NestedAttributeObject{
 					Computed:    true,
 					Description: "The CPU units.",
 				},
+				"cpu_limit": schema.Float64Attribute{
+					Computed:    true,
+					Description: "The limit of host CPU time the VM can use, in CPUs.",
+				},
+				"vcpus": schema.Int64Attribute{
+					Computed:    true,
+					Description: "The number of vCPUs plugged in at boot.",
+				},
+				"affinity": schema.StringAttribute{
+					Computed:    true,
+					Description: "The host cores the VM is pinned to.",
+				},
+				"flags": schema.SetAttribute{
+					Computed:    true,
+					Description: "The CPU flags enabled or disabled on top of the emulated type.",
+					ElementType: types.StringType,
+				},
+				"hidden": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether the VM is hidden from being identified as a KVM guest.",
+				},
+				"hv_vendor_id": schema.StringAttribute{
+					Computed:    true,
+					Description: "The Hyper-V vendor id.",
+				},
+				"numa": schema.BoolAttribute{
+					Computed:    true,
+					Description: "Whether NUMA is enabled.",
+				},
+				"numa_nodes": schema.ListNestedAttribute{
+					Computed:     true,
+					Description:  "The NUMA nodes of the VM.",
+					NestedObject: qs.CpuNumaNodeObjectDataSourceSchema,
+				},
 			},
 		},
 		"disks": schema.SetNestedAttribute{
@@ -98,6 +132,60 @@ var VirtualMachineDataSourceSchema = schema.NestedAttributeObject{
 			CustomType:   qt.NewVirtualMachinePCIDeviceSetType(),
 			NestedObject: qs.PCIDeviceObjectDataSourceSchema,
 		},
+		"serial_devices": schema.SetNestedAttribute{
+			Computed:     true,
+			Description:  "The serial devices attached to the VM.",
+			NestedObject: qs.SerialDeviceObjectDataSourceSchema,
+		},
+		"usb_devices": schema.SetNestedAttribute{
+			Computed:     true,
+			Description:  "The USB devices attached to the VM.",
+			NestedObject: qs.USBDeviceObjectDataSourceSchema,
+		},
+		"display": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The display configuration.",
+			Attributes:  qs.DisplayDataSourceAttributes,
+		},
+		"rng": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The VirtIO random number generator.",
+			Attributes:  qs.RngDataSourceAttributes,
+		},
+		"watchdog": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The watchdog device.",
+			Attributes:  qs.WatchdogDataSourceAttributes,
+		},
+		"audio": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The audio device.",
+			Attributes:  qs.AudioDataSourceAttributes,
+		},
+		"ipv4_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv4 addresses reported by the guest agent, excluding loopback and link local addresses.",
+			ElementType: types.StringType,
+		},
+		"ipv6_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv6 addresses reported by the guest agent, excluding loopback and link local addresses.",
+			ElementType: types.StringType,
+		},
+		"guest_network_interfaces": schema.ListNestedAttribute{
+			Computed:     true,
+			Description:  "The network interfaces reported by the guest agent.",
+			NestedObject: qs.GuestNetworkInterfaceObjectDataSourceSchema,
+		},
+		"hostname": schema.StringAttribute{
+			Computed:    true,
+			Description: "The hostname reported by the guest agent.",
+		},
+		"os_info": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The operating system reported by the guest agent.",
+			Attributes:  qs.GuestOsInfoDataSourceAttributes,
+		},
 		"network_interfaces": schema.SetNestedAttribute{
 			Computed:     true,
 			CustomType:   qt.NewVirtualMachineNetworkInterfaceSetType(),
@@ -118,6 +206,10 @@ var VirtualMachineDataSourceSchema = schema.NestedAttributeObject{
 					Computed:    true,
 					Description: "The shared memory in MB.",
 				},
+				"hugepages": schema.StringAttribute{
+					Computed:    true,
+					Description: "The size of the hugepages backing the memory.",
+				},
 			},
 		},
 		"machine_type": schema.StringAttribute{
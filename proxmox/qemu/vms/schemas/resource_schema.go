@@ -1,10 +1,15 @@
 package schemas
 
 import (
+	"context"
+	"regexp"
+
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/defaults"
 	qs "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/schemas"
 	t "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -13,7 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -29,15 +34,40 @@ var ResourceSchema = schema.Schema{
 			Computed:    true,
 			Description: "The identifier of the virtual machine.",
 			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
 				int64planmodifier.RequiresReplace(),
 			},
 		},
+		"id_range": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The range to allocate the identifier from when `id` is not set. Defaults to the next free identifier of the cluster.",
+			Validators: []validator.Object{
+				objectvalidator.ConflictsWith(path.Expressions{
+					path.MatchRoot("id"),
+				}...),
+			},
+			Attributes: map[string]schema.Attribute{
+				"start": schema.Int64Attribute{
+					Required:    true,
+					Description: "The first identifier of the range.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(100),
+						int64validator.AtMost(999999999),
+					},
+				},
+				"end": schema.Int64Attribute{
+					Required:    true,
+					Description: "The last identifier of the range.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(100),
+						int64validator.AtMost(999999999),
+					},
+				},
+			},
+		},
 		"node": schema.StringAttribute{
 			Required:    true,
-			Description: "The node to create the virtual machine on.",
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
-			},
+			Description: "The node to create the virtual machine on. Changing the node migrates the virtual machine, online if it is running.",
 		},
 		"name": schema.StringAttribute{
 			Optional:    true,
@@ -66,10 +96,13 @@ var ResourceSchema = schema.Schema{
 			Validators: []validator.Object{
 				objectvalidator.ConflictsWith(path.Expressions{
 					path.MatchRoot("iso"),
+					path.MatchRoot("restore"),
+					path.MatchRoot("cloud_image"),
+					path.MatchRoot("pxe"),
 				}...),
 			},
 			PlanModifiers: []planmodifier.Object{
-				objectplanmodifier.RequiresReplace(),
+				creationObjectRequiresReplace(),
 			},
 			Attributes: map[string]schema.Attribute{
 				"storage": schema.StringAttribute{
@@ -77,7 +110,7 @@ var ResourceSchema = schema.Schema{
 					Computed:    true,
 					Description: "The storage to place the clone on.",
 					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
+						creationStringRequiresReplace(),
 						defaults.DefaultString("local"),
 					},
 				},
@@ -85,7 +118,7 @@ var ResourceSchema = schema.Schema{
 					Required:    true,
 					Description: "The identifier of the virtual machine or template to clone.",
 					PlanModifiers: []planmodifier.Int64{
-						int64planmodifier.RequiresReplace(),
+						creationInt64RequiresReplace(),
 					},
 					Validators: []validator.Int64{
 						int64validator.AtLeast(100),
@@ -97,7 +130,7 @@ var ResourceSchema = schema.Schema{
 					Computed:    true,
 					Description: "Whether to clone as a full or linked clone.",
 					PlanModifiers: []planmodifier.Bool{
-						boolplanmodifier.RequiresReplace(),
+						creationBoolRequiresReplace(),
 						defaults.DefaultBool(true),
 					},
 				},
@@ -111,28 +144,230 @@ var ResourceSchema = schema.Schema{
 					Required:    true,
 					Description: "The storage to place install media on.",
 					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
+						creationStringRequiresReplace(),
 					},
 				},
 				"image": schema.StringAttribute{
 					Required:    true,
 					Description: "The image to use for install media.",
 					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
+						creationStringRequiresReplace(),
 					},
 				},
 			},
 			Validators: []validator.Object{
 				objectvalidator.ConflictsWith(path.Expressions{
 					path.MatchRoot("clone"),
+					path.MatchRoot("restore"),
+					path.MatchRoot("cloud_image"),
+					path.MatchRoot("pxe"),
 				}...),
 			},
 			PlanModifiers: []planmodifier.Object{
-				objectplanmodifier.RequiresReplace(),
+				creationObjectRequiresReplace(),
 			},
 		}, // method for installing from media
-		// "cloud_image": schema.SingleNestedAttribute{}, // will require some janky stuff to get working
-		// "pxe": schema.SingleNestedAttribute{},
+		"restore": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The backup to restore the virtual machine from.",
+			Attributes: map[string]schema.Attribute{
+				"archive": schema.StringAttribute{
+					Required:    true,
+					Description: "The volume id of the vzdump or Proxmox Backup Server archive to restore.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+				},
+				"storage": schema.StringAttribute{
+					Optional:    true,
+					Description: "The storage to restore the disks to. Defaults to the storage the disks were backed up from.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+				},
+				"unique": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to assign new MAC addresses to the network interfaces of the restored virtual machine.",
+					PlanModifiers: []planmodifier.Bool{
+						creationBoolRequiresReplace(),
+						defaults.DefaultBool(true),
+					},
+				},
+				"bandwidth_limit": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The I/O bandwidth limit of the restore in KiB/s. Only applies to the initial restore.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.ConflictsWith(path.Expressions{
+					path.MatchRoot("clone"),
+					path.MatchRoot("iso"),
+					path.MatchRoot("cloud_image"),
+					path.MatchRoot("pxe"),
+				}...),
+			},
+			PlanModifiers: []planmodifier.Object{
+				creationObjectRequiresReplace(),
+			},
+		}, // method for restoring from a backup
+		"cloud_image": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The cloud image to create the virtual machine from. The image is imported as the boot disk.",
+			Attributes: map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					Optional:    true,
+					Description: "The URL to download the image from. The download is skipped if the file already exists on the download storage, unless `checksum` is set, in which case the file is downloaded again and verified.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(path.Expressions{
+							path.MatchRelative().AtParent().AtName("volume"),
+						}...),
+						stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "url must be a http or https URL"),
+					},
+				},
+				"volume": schema.StringAttribute{
+					Optional:    true,
+					Description: "The volume id of an image already on a storage.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+				},
+				"checksum": schema.StringAttribute{
+					Optional:    true,
+					Description: "The expected checksum of the downloaded image.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(path.Expressions{
+							path.MatchRelative().AtParent().AtName("checksum_algorithm"),
+							path.MatchRelative().AtParent().AtName("url"),
+						}...),
+					},
+				},
+				"checksum_algorithm": schema.StringAttribute{
+					Optional:    true,
+					Description: "The algorithm of the checksum.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							"md5",
+							"sha1",
+							"sha224",
+							"sha256",
+							"sha384",
+							"sha512",
+						),
+						stringvalidator.AlsoRequires(path.Expressions{
+							path.MatchRelative().AtParent().AtName("checksum"),
+						}...),
+					},
+				},
+				"download_storage": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The storage to download the image to. The storage must allow `iso` content.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+						defaults.DefaultString("local"),
+					},
+				},
+				"file_name": schema.StringAttribute{
+					Optional:    true,
+					Description: "The name to save the downloaded image as. Defaults to the file name in the URL, with `.img` appended when needed.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`\.(img|iso)$`), "file_name must end in `.img` or `.iso`"),
+					},
+				},
+				"storage": schema.StringAttribute{
+					Required:    true,
+					Description: "The storage to import the image disk to.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+					},
+				},
+				"disk": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The disk to import the image as. Configure a disk with the same interface and position to resize it.",
+					PlanModifiers: []planmodifier.String{
+						creationStringRequiresReplace(),
+						defaults.DefaultString("scsi0"),
+					},
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^(ide|sata|scsi|virtio)[0-9]+$`), "disk must follow scheme `<interface><position>`"),
+					},
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.ConflictsWith(path.Expressions{
+					path.MatchRoot("clone"),
+					path.MatchRoot("iso"),
+					path.MatchRoot("restore"),
+					path.MatchRoot("pxe"),
+				}...),
+			},
+			PlanModifiers: []planmodifier.Object{
+				creationObjectRequiresReplace(),
+			},
+		}, // method for importing a cloud image
+		"pxe": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Create an empty virtual machine that boots from the network.",
+			Attributes: map[string]schema.Attribute{
+				"network_interface": schema.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The position of the network interface to boot from.",
+					PlanModifiers: []planmodifier.Int64{
+						creationInt64RequiresReplace(),
+						defaults.DefaultInt64(0),
+					},
+					Validators: []validator.Int64{
+						int64validator.Between(0, 31),
+					},
+				},
+				"revert_boot_order": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to switch to `boot_order`, or to the disks when it is not set, once the guest agent reports the first boot of the installed system. Requires the agent to be enabled and `start_on_create`.",
+					PlanModifiers: []planmodifier.Bool{
+						creationBoolRequiresReplace(),
+						defaults.DefaultBool(false),
+					},
+				},
+				"first_boot_timeout": schema.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "How long to wait in seconds for the installed system to boot before reverting the boot order.",
+					PlanModifiers: []planmodifier.Int64{
+						defaults.DefaultInt64(3600),
+					},
+				},
+			},
+			Validators: []validator.Object{
+				objectvalidator.ConflictsWith(path.Expressions{
+					path.MatchRoot("clone"),
+					path.MatchRoot("iso"),
+					path.MatchRoot("restore"),
+					path.MatchRoot("cloud_image"),
+				}...),
+			},
+			PlanModifiers: []planmodifier.Object{
+				creationObjectRequiresReplace(),
+			},
+		}, // method for booting from the network
 		// configuration
 		"agent": schema.SingleNestedAttribute{
 			Optional:    true,
@@ -203,6 +438,14 @@ var ResourceSchema = schema.Schema{
 					"sockets":       types.Int64Value(1),
 					"emulated_type": types.StringValue("kvm64"),
 					"cpu_units":     types.Int64Value(100),
+					"cpu_limit":     types.Float64Null(),
+					"vcpus":         types.Int64Null(),
+					"affinity":      types.StringNull(),
+					"flags":         types.SetNull(types.StringType),
+					"hidden":        types.BoolValue(false),
+					"hv_vendor_id":  types.StringNull(),
+					"numa":          types.BoolValue(false),
+					"numa_nodes":    types.ListNull(t.VirtualMachineCpuNumaNode),
 				}),
 			},
 			// requires root, make computed til this can be warned about
@@ -301,6 +544,69 @@ var ResourceSchema = schema.Schema{
 					Optional:    true,
 					Description: "The CPU units.",
 				},
+				"cpu_limit": schema.Float64Attribute{
+					Optional:    true,
+					Description: "The limit of host CPU time the VM can use, in CPUs. Unset means unlimited.",
+					Validators: []validator.Float64{
+						float64validator.Between(0, 128),
+					},
+				},
+				"vcpus": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The number of vCPUs plugged in at boot, used with the `cpu` hotplug feature to add vCPUs later. Must not exceed `cores` times `sockets`.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"affinity": schema.StringAttribute{
+					Optional:    true,
+					Description: "The host cores to pin the VM to, as ids or ranges separated by `,`, such as `0-3,8`. Can only be set by root.",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`), "affinity must be core ids or ranges separated by `,`"),
+					},
+				},
+				"flags": schema.SetAttribute{
+					Optional:    true,
+					Description: "CPU flags to enable or disable on top of the emulated type, such as `+aes` or `-pcid`.",
+					ElementType: types.StringType,
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(
+							stringvalidator.RegexMatches(regexp.MustCompile(`^[+-][a-zA-Z0-9._-]+$`), "flags must start with `+` or `-`"),
+						),
+					},
+				},
+				"hidden": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to hide that the VM is a KVM guest.",
+					PlanModifiers: []planmodifier.Bool{
+						defaults.DefaultBool(false),
+					},
+				},
+				"hv_vendor_id": schema.StringAttribute{
+					Optional:    true,
+					Description: "The Hyper-V vendor id reported to the guest.",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9]{1,12}$`), "hv_vendor_id must be up to 12 alphanumeric characters"),
+					},
+				},
+				"numa": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to enable NUMA.",
+					PlanModifiers: []planmodifier.Bool{
+						defaults.DefaultBool(false),
+					},
+				},
+				"numa_nodes": schema.ListNestedAttribute{
+					Optional:     true,
+					Description:  "The NUMA nodes of the VM, in order from `numa0`. Requires `numa`.",
+					NestedObject: qs.CpuNumaNodeObjectSchema,
+					Validators: []validator.List{
+						listvalidator.SizeBetween(1, 8),
+					},
+				},
 			},
 		},
 		"disks": schema.SetNestedAttribute{
@@ -318,6 +624,122 @@ var ResourceSchema = schema.Schema{
 			CustomType:   t.NewVirtualMachineDiskSetType(),
 			NestedObject: qs.DiskObjectSchema,
 		},
+		"efi_disk": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The EFI disk storing the UEFI variables. Requires the `ovmf` BIOS. Changing it recreates the disk, removing it stops it from being managed.",
+			Attributes: map[string]schema.Attribute{
+				"storage": schema.StringAttribute{
+					Required:    true,
+					Description: "The storage to create the EFI disk on.",
+				},
+				"type": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The size of the EFI variable store. `4m` is required for secure boot.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							"2m",
+							"4m",
+						),
+					},
+					PlanModifiers: []planmodifier.String{
+						defaults.DefaultString("4m"),
+					},
+				},
+				"pre_enrolled_keys": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to enroll the distribution and Microsoft secure boot keys, enabling secure boot.",
+					PlanModifiers: []planmodifier.Bool{
+						defaults.DefaultBool(false),
+					},
+				},
+			},
+		},
+		"tpm_state": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The disk storing the state of the virtual TPM. Changing it recreates the disk, removing it stops it from being managed.",
+			Attributes: map[string]schema.Attribute{
+				"storage": schema.StringAttribute{
+					Required:    true,
+					Description: "The storage to create the TPM state disk on.",
+				},
+				"version": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The TPM version. Windows 11 requires `v2.0`.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							"v1.2",
+							"v2.0",
+						),
+					},
+					PlanModifiers: []planmodifier.String{
+						defaults.DefaultString("v2.0"),
+					},
+				},
+			},
+		},
+		"serial_devices": schema.SetNestedAttribute{
+			Optional:     true,
+			Description:  "The serial devices attached to the VM. Removing the attribute stops the devices from being managed.",
+			NestedObject: qs.SerialDeviceObjectSchema,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"usb_devices": schema.SetNestedAttribute{
+			Optional:     true,
+			Description:  "The USB devices attached to the VM. Each device passes through exactly one of `host_id`, `host_port`, `spice` or `mapping`. Removing the attribute stops the devices from being managed.",
+			NestedObject: qs.USBDeviceObjectSchema,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"display": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The display configuration. Removing the attribute resets the display to the default.",
+			Attributes:  qs.DisplayAttributes,
+		},
+		"rng": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The VirtIO random number generator, passing entropy from the host to the guest. Removing the attribute removes the device.",
+			Attributes:  qs.RngAttributes,
+		},
+		"watchdog": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The watchdog device. Removing the attribute removes the device.",
+			Attributes:  qs.WatchdogAttributes,
+		},
+		"audio": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The audio device. Removing the attribute removes the device.",
+			Attributes:  qs.AudioAttributes,
+		},
+		"ipv4_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv4 addresses reported by the guest agent, excluding loopback and link local addresses.",
+			ElementType: types.StringType,
+		},
+		"ipv6_addresses": schema.ListAttribute{
+			Computed:    true,
+			Description: "The IPv6 addresses reported by the guest agent, excluding loopback and link local addresses.",
+			ElementType: types.StringType,
+		},
+		"guest_network_interfaces": schema.ListNestedAttribute{
+			Computed:     true,
+			Description:  "The network interfaces reported by the guest agent.",
+			NestedObject: qs.GuestNetworkInterfaceObjectSchema,
+		},
+		"hostname": schema.StringAttribute{
+			Computed:    true,
+			Description: "The hostname reported by the guest agent.",
+		},
+		"os_info": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The operating system reported by the guest agent.",
+			Attributes:  qs.GuestOsInfoAttributes,
+		},
 		"pci_devices": schema.SetNestedAttribute{
 			Optional:     true,
 			Description:  "PCI devices passed through to the VM.",
@@ -357,6 +779,7 @@ var ResourceSchema = schema.Schema{
 					"dedicated": types.Int64Value(1024),
 					"floating":  types.Int64Null(),
 					"shared":    types.Int64Null(),
+					"hugepages": types.StringNull(),
 				}),
 			},
 			Attributes: map[string]schema.Attribute{
@@ -382,6 +805,17 @@ var ResourceSchema = schema.Schema{
 						defaults.DefaultInt64Null(),
 					},
 				},
+				"hugepages": schema.StringAttribute{
+					Optional:    true,
+					Description: "The size of the hugepages backing the memory. Either `2` or `1024` MB, or `any`. Requires NUMA and hugepages allocated on the host.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							"any",
+							"2",
+							"1024",
+						),
+					},
+				},
 			},
 		},
 		"machine_type": schema.StringAttribute{
@@ -479,6 +913,85 @@ var ResourceSchema = schema.Schema{
 				defaults.DefaultBool(true),
 			},
 		},
+		"template": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to convert the virtual machine into a template once it is configured. A template is never started. Converting a template back into a virtual machine replaces it.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+				boolplanmodifier.RequiresReplaceIf(
+					func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+					},
+					"Templates cannot be converted back into virtual machines.",
+					"Templates cannot be converted back into virtual machines.",
+				),
+			},
+		},
+		"migration": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Options used when the virtual machine is migrated to another node.",
+			Attributes: map[string]schema.Attribute{
+				"with_local_disks": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether to migrate disks on local storage along with the virtual machine.",
+					PlanModifiers: []planmodifier.Bool{
+						defaults.DefaultBool(false),
+					},
+				},
+				"target_storage": schema.StringAttribute{
+					Optional:    true,
+					Description: "The storage on the target node to place all local disks on. The `storage` of the affected disks should be changed to match.",
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(path.Expressions{
+							path.MatchRelative().AtParent().AtName("storage_mapping"),
+						}...),
+					},
+				},
+				"storage_mapping": schema.MapAttribute{
+					Optional:    true,
+					Description: "A mapping of source storage to storage on the target node to place local disks on. The `storage` of the affected disks should be changed to match.",
+					ElementType: types.StringType,
+				},
+			},
+		},
+		"boot_order": schema.ListAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The devices to boot from in order, such as `scsi0`, `net0` or `ide2`. Each device must be a declared disk or network interface, or `ide2` when the virtual machine was created from an iso.",
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(ide|sata|scsi|virtio|net)\d+$`), "must be a disk or network interface such as scsi0 or net0"),
+				),
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"hotplug": schema.SetAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The devices that can be hotplugged into the running virtual machine. Changes to hotpluggable devices are applied without restarting the virtual machine. An empty set disables hotplug.",
+			ElementType: types.StringType,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(
+					stringvalidator.OneOf(
+						"network",
+						"disk",
+						"cpu",
+						"memory",
+						"usb",
+						"cloudinit",
+					),
+				),
+			},
+			PlanModifiers: []planmodifier.Set{
+				setplanmodifier.UseStateForUnknown(),
+			},
+		},
 		"start_on_node_boot": schema.BoolAttribute{
 			Optional:    true,
 			Computed:    true,
@@ -487,6 +1000,54 @@ var ResourceSchema = schema.Schema{
 				defaults.DefaultBool(true),
 			},
 		},
+		"wait_for_ip": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Wait for the guest agent to report an IP address after the virtual machine is started, so addresses assigned by DHCP are known once the apply completes. Requires the agent to be enabled.",
+			Attributes: map[string]schema.Attribute{
+				"timeout": schema.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "How long to wait in seconds for an address.",
+					PlanModifiers: []planmodifier.Int64{
+						defaults.DefaultInt64(300),
+					},
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"interface": schema.StringAttribute{
+					Optional:    true,
+					Description: "The name of the guest interface the address must be on, such as `eth0`.",
+				},
+				"cidr": schema.StringAttribute{
+					Optional:    true,
+					Description: "The network the address must be in, such as `10.0.0.0/24`.",
+				},
+			},
+		},
+		"stop_strategy": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "How to stop the virtual machine when it is deleted or a change requires it to be powered off. `shutdown` requests a guest shutdown, `stop` powers the virtual machine off, and `shutdown_then_stop` powers it off if the shutdown does not complete within the shutdown timeout.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"shutdown",
+					"stop",
+					"shutdown_then_stop",
+				),
+			},
+			PlanModifiers: []planmodifier.String{
+				defaults.DefaultString("shutdown_then_stop"),
+			},
+		},
+		"reboot_on_pending_changes": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to reboot the running virtual machine when a change can't be hotplugged. Otherwise the change is left pending until the virtual machine is restarted.",
+			PlanModifiers: []planmodifier.Bool{
+				defaults.DefaultBool(false),
+			},
+		},
 		"timeouts": schema.SingleNestedAttribute{
 			Optional: true,
 			Attributes: map[string]schema.Attribute{
@@ -526,6 +1087,10 @@ var ResourceSchema = schema.Schema{
 					Optional:    true,
 					Description: "The timeout for resizing disk the virtual machine.",
 				},
+				"migrate": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The timeout for migrating the virtual machine.",
+				},
 			},
 		},
 	},
@@ -0,0 +1,45 @@
+package vms
+
+import (
+	"context"
+
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func isTemplateConversion(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) bool {
+	if !plan.Template.ValueBool() {
+		return false
+	}
+	return state == nil || !state.Template.ValueBool()
+}
+
+// stops the virtual machine if it is running and converts it into a template
+func (r *virtualMachineResource) convertToTemplate(ctx context.Context, plan *vt.VirtualMachineResourceModel) error {
+	node := plan.Node.ValueString()
+	vmId := int(plan.ID.ValueInt64())
+
+	running, err := r.isRunning(ctx, node, vmId)
+	if err != nil {
+		return err
+	}
+	if running {
+		tflog.Debug(ctx, "Stopping virtual machine before template conversion")
+		err = r.stopVm(ctx, node, vmId, StopStrategy(plan.StopStrategy.ValueString()))
+		if err != nil {
+			return err
+		}
+	}
+
+	tflog.Debug(ctx, "Converting virtual machine to template")
+	upid, err := r.client.ConvertVirtualMachineToTemplate(ctx, node, vmId)
+	if err != nil {
+		return err
+	}
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Configure)
+	if err != nil {
+		return err
+	}
+
+	return r.client.WaitForVirtualMachineLock(ctx, node, vmId, r.timeouts.Configure)
+}
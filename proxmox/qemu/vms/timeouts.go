@@ -5,6 +5,7 @@ import (
 	"time"
 
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
 )
 
 type VirtualMachineTimeouts struct {
@@ -17,18 +18,20 @@ type VirtualMachineTimeouts struct {
 	Clone      int64
 	Configure  int64
 	ResizeDisk int64
+	Migrate    int64
 }
 
 var timeoutDefaults = VirtualMachineTimeouts{
-	Create:     600,
-	Delete:     600,
-	Stop:       600,
-	Start:      600,
-	Reboot:     600,
-	Shutdown:   600,
-	Clone:      600,
-	Configure:  600,
-	ResizeDisk: 600,
+	Create:     utils.DefaultTimeout,
+	Delete:     utils.DefaultTimeout,
+	Stop:       utils.DefaultTimeout,
+	Start:      utils.DefaultTimeout,
+	Reboot:     utils.DefaultTimeout,
+	Shutdown:   utils.DefaultTimeout,
+	Clone:      utils.DefaultTimeout,
+	Configure:  utils.DefaultTimeout,
+	ResizeDisk: utils.DefaultTimeout,
+	Migrate:    1800,
 }
 
 func loadTimeouts(ctx context.Context, timeouts *vt.VirtualMachineTerraformTimeouts) *VirtualMachineTimeouts {
@@ -37,50 +40,16 @@ func loadTimeouts(ctx context.Context, timeouts *vt.VirtualMachineTerraformTimeo
 		return &t
 	}
 
-	if !timeouts.Create.IsNull() || !timeouts.Create.IsUnknown() {
-		create := int64(timeouts.Create.ValueInt64())
-		t.Create = create
-	}
-
-	if !timeouts.Delete.IsNull() || !timeouts.Delete.IsUnknown() {
-		delete := int64(timeouts.Delete.ValueInt64())
-		t.Delete = delete
-	}
-
-	if !timeouts.Stop.IsNull() || !timeouts.Stop.IsUnknown() {
-		stop := int64(timeouts.Stop.ValueInt64())
-		t.Stop = stop
-	}
-
-	if !timeouts.Start.IsNull() || !timeouts.Start.IsUnknown() {
-		start := int64(timeouts.Start.ValueInt64())
-		t.Start = start
-	}
-
-	if !timeouts.Reboot.IsNull() || !timeouts.Reboot.IsUnknown() {
-		reboot := int64(timeouts.Reboot.ValueInt64())
-		t.Reboot = reboot
-	}
-
-	if !timeouts.Shutdown.IsNull() || !timeouts.Shutdown.IsUnknown() {
-		shutdown := int64(timeouts.Shutdown.ValueInt64())
-		t.Shutdown = shutdown
-	}
-
-	if !timeouts.Clone.IsNull() || !timeouts.Clone.IsUnknown() {
-		clone := int64(timeouts.Clone.ValueInt64())
-		t.Clone = clone
-	}
-
-	if !timeouts.Configure.IsNull() || !timeouts.Configure.IsUnknown() {
-		clone := int64(timeouts.Configure.ValueInt64())
-		t.Clone = clone
-	}
-
-	if !timeouts.ResizeDisk.IsNull() || !timeouts.ResizeDisk.IsUnknown() {
-		clone := int64(timeouts.ResizeDisk.ValueInt64())
-		t.Clone = clone
-	}
+	t.Create = utils.LoadTimeout(timeouts.Create, t.Create)
+	t.Delete = utils.LoadTimeout(timeouts.Delete, t.Delete)
+	t.Stop = utils.LoadTimeout(timeouts.Stop, t.Stop)
+	t.Start = utils.LoadTimeout(timeouts.Start, t.Start)
+	t.Reboot = utils.LoadTimeout(timeouts.Reboot, t.Reboot)
+	t.Shutdown = utils.LoadTimeout(timeouts.Shutdown, t.Shutdown)
+	t.Clone = utils.LoadTimeout(timeouts.Clone, t.Clone)
+	t.Configure = utils.LoadTimeout(timeouts.Configure, t.Configure)
+	t.ResizeDisk = utils.LoadTimeout(timeouts.ResizeDisk, t.ResizeDisk)
+	t.Migrate = utils.LoadTimeout(timeouts.Migrate, t.Migrate)
 
 	return &t
 }
@@ -0,0 +1,26 @@
+package vms
+
+import (
+	"context"
+	"testing"
+
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTimeouts(t *testing.T) {
+	assert.Equal(t, timeoutDefaults, *loadTimeouts(context.Background(), nil))
+
+	timeouts := loadTimeouts(context.Background(), &vt.VirtualMachineTerraformTimeouts{
+		Create:     types.Int64Value(900),
+		Configure:  types.Int64Value(120),
+		ResizeDisk: types.Int64Value(60),
+		Shutdown:   types.Int64Unknown(),
+	})
+	expected := timeoutDefaults
+	expected.Create = 900
+	expected.Configure = 120
+	expected.ResizeDisk = 60
+	assert.Equal(t, expected, *timeouts)
+}
@@ -11,25 +11,36 @@ import (
 )
 
 type VirtualMachineDataSourceModel struct {
-	ID                types.Int64                               `tfsdk:"id"`
-	Node              types.String                              `tfsdk:"node"`
-	Name              types.String                              `tfsdk:"name"`
-	Description       types.String                              `tfsdk:"description"`
-	Tags              types.Set                                 `tfsdk:"tags"`
-	Agent             *qt.VirtualMachineAgentModel              `tfsdk:"agent"`
-	BIOS              types.String                              `tfsdk:"bios"`
-	CPU               qt.VirtualMachineCpuModel                 `tfsdk:"cpu"`
-	Disks             qt.VirtualMachineDiskSetValue             `tfsdk:"disks"`
-	PCIDevices        qt.VirtualMachinePCIDeviceSetValue        `tfsdk:"pci_devices"`
-	NetworkInterfaces qt.VirtualMachineNetworkInterfaceSetValue `tfsdk:"network_interfaces"`
-	Memory            qt.VirtualMachineMemoryModel              `tfsdk:"memory"`
-	MachineType       types.String                              `tfsdk:"machine_type"`
-	KVMArguments      types.String                              `tfsdk:"kvm_arguments"`
-	KeyboardLayout    types.String                              `tfsdk:"keyboard_layout"`
-	CloudInit         *qt.VirtualMachineCloudInitModel          `tfsdk:"cloud_init"`
-	Type              types.String                              `tfsdk:"type"`
-	ResourcePool      types.String                              `tfsdk:"resource_pool"`
-	StartOnNodeBoot   types.Bool                                `tfsdk:"start_on_node_boot"`
+	ID                     types.Int64                               `tfsdk:"id"`
+	Node                   types.String                              `tfsdk:"node"`
+	Name                   types.String                              `tfsdk:"name"`
+	Description            types.String                              `tfsdk:"description"`
+	Tags                   types.Set                                 `tfsdk:"tags"`
+	Agent                  *qt.VirtualMachineAgentModel              `tfsdk:"agent"`
+	BIOS                   types.String                              `tfsdk:"bios"`
+	CPU                    qt.VirtualMachineCpuModel                 `tfsdk:"cpu"`
+	Disks                  qt.VirtualMachineDiskSetValue             `tfsdk:"disks"`
+	PCIDevices             qt.VirtualMachinePCIDeviceSetValue        `tfsdk:"pci_devices"`
+	SerialDevices          []qt.VirtualMachineSerialDeviceModel      `tfsdk:"serial_devices"`
+	USBDevices             []qt.VirtualMachineUSBDeviceModel         `tfsdk:"usb_devices"`
+	Display                *qt.VirtualMachineDisplayModel            `tfsdk:"display"`
+	Rng                    *qt.VirtualMachineRngModel                `tfsdk:"rng"`
+	Watchdog               *qt.VirtualMachineWatchdogModel           `tfsdk:"watchdog"`
+	Audio                  *qt.VirtualMachineAudioModel              `tfsdk:"audio"`
+	NetworkInterfaces      qt.VirtualMachineNetworkInterfaceSetValue `tfsdk:"network_interfaces"`
+	IPv4Addresses          types.List                                `tfsdk:"ipv4_addresses"`
+	IPv6Addresses          types.List                                `tfsdk:"ipv6_addresses"`
+	GuestNetworkInterfaces types.List                                `tfsdk:"guest_network_interfaces"`
+	Hostname               types.String                              `tfsdk:"hostname"`
+	OsInfo                 types.Object                              `tfsdk:"os_info"`
+	Memory                 qt.VirtualMachineMemoryModel              `tfsdk:"memory"`
+	MachineType            types.String                              `tfsdk:"machine_type"`
+	KVMArguments           types.String                              `tfsdk:"kvm_arguments"`
+	KeyboardLayout         types.String                              `tfsdk:"keyboard_layout"`
+	CloudInit              *qt.VirtualMachineCloudInitModel          `tfsdk:"cloud_init"`
+	Type                   types.String                              `tfsdk:"type"`
+	ResourcePool           types.String                              `tfsdk:"resource_pool"`
+	StartOnNodeBoot        types.Bool                                `tfsdk:"start_on_node_boot"`
 }
 
 func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDataSourceModel {
@@ -45,6 +56,12 @@ func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDa
 		PCIDevices:        qt.VirtualMachinePCIDeviceToSetValue(ctx, v.PCIDevices),
 		CloudInit:         qt.CloudInitToModel(ctx, v.CloudInit),
 		StartOnNodeBoot:   types.BoolValue(v.StartOnBoot),
+		SerialDevices:     qt.VMSerialDevicesToModel(v.SerialDevices),
+		USBDevices:        qt.VMUSBDevicesToModel(v.USBDevices),
+		Display:           qt.VMDisplayToModel(v.Display),
+		Rng:               qt.VMRngToModel(v.Rng),
+		Watchdog:          qt.VMWatchdogToModel(v.Watchdog),
+		Audio:             qt.VMAudioToModel(v.Audio),
 	}
 
 	if v.Description != nil {
@@ -73,6 +90,13 @@ func VMToModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineDa
 		m.Agent = &a
 	}
 
+	guest := qt.VMGuestToModel(ctx, v.Guest)
+	m.IPv4Addresses = guest.IPv4Addresses
+	m.IPv6Addresses = guest.IPv6Addresses
+	m.GuestNetworkInterfaces = guest.NetworkInterfaces
+	m.Hostname = guest.Hostname
+	m.OsInfo = guest.OsInfo
+
 	return &m
 }
 
@@ -99,6 +123,7 @@ func VMCPUToModel(cpu *vm.VirtualMachineCpu) qt.VirtualMachineCpuModel {
 	if cpu.CpuUnits != nil {
 		m.CPUUnits = types.Int64Value(int64(*cpu.CpuUnits))
 	}
+	qt.VMCPUOptionsToModel(cpu, &m)
 
 	return m
 }
@@ -116,5 +141,9 @@ func VMMemoryToModel(memory *vm.VirtualMachineMemory) qt.VirtualMachineMemoryMod
 		m.Shared = types.Int64Value(int64(*memory.Shared))
 	}
 
+	if memory.Hugepages != nil {
+		m.Hugepages = types.StringValue(*memory.Hugepages)
+	}
+
 	return m
 }
@@ -22,6 +22,7 @@ type VirtualMachineTerraformTimeouts struct {
 	Clone      types.Int64 `tfsdk:"clone"`
 	Configure  types.Int64 `tfsdk:"configure"`
 	ResizeDisk types.Int64 `tfsdk:"resize_disk"`
+	Migrate    types.Int64 `tfsdk:"migrate"`
 }
 
 type VirtualMachineCloneModel struct {
@@ -30,6 +31,47 @@ type VirtualMachineCloneModel struct {
 	FullClone types.Bool   `tfsdk:"full_clone"`
 }
 
+type VirtualMachineIdRangeModel struct {
+	Start types.Int64 `tfsdk:"start"`
+	End   types.Int64 `tfsdk:"end"`
+}
+
+type VirtualMachineMigrationModel struct {
+	WithLocalDisks types.Bool   `tfsdk:"with_local_disks"`
+	TargetStorage  types.String `tfsdk:"target_storage"`
+	StorageMapping types.Map    `tfsdk:"storage_mapping"`
+}
+
+type VirtualMachineRestoreModel struct {
+	Archive        types.String `tfsdk:"archive"`
+	Storage        types.String `tfsdk:"storage"`
+	Unique         types.Bool   `tfsdk:"unique"`
+	BandwidthLimit types.Int64  `tfsdk:"bandwidth_limit"`
+}
+
+type VirtualMachineCloudImageModel struct {
+	Url               types.String `tfsdk:"url"`
+	Volume            types.String `tfsdk:"volume"`
+	Checksum          types.String `tfsdk:"checksum"`
+	ChecksumAlgorithm types.String `tfsdk:"checksum_algorithm"`
+	DownloadStorage   types.String `tfsdk:"download_storage"`
+	FileName          types.String `tfsdk:"file_name"`
+	Storage           types.String `tfsdk:"storage"`
+	Disk              types.String `tfsdk:"disk"`
+}
+
+type VirtualMachinePxeModel struct {
+	NetworkInterface types.Int64 `tfsdk:"network_interface"`
+	RevertBootOrder  types.Bool  `tfsdk:"revert_boot_order"`
+	FirstBootTimeout types.Int64 `tfsdk:"first_boot_timeout"`
+}
+
+type VirtualMachineWaitForIpModel struct {
+	Timeout   types.Int64  `tfsdk:"timeout"`
+	Interface types.String `tfsdk:"interface"`
+	CIDR      types.String `tfsdk:"cidr"`
+}
+
 type VirtualMachineIsoModel struct {
 	Storage *types.String `tfsdk:"storage"`
 	Image   *types.String `tfsdk:"image"`
@@ -42,21 +84,38 @@ type T struct {
 
 type VirtualMachineResourceModel struct {
 	ID                        types.Int64                               `tfsdk:"id"`
+	IDRange                   *VirtualMachineIdRangeModel               `tfsdk:"id_range"`
 	Node                      types.String                              `tfsdk:"node"`
 	Name                      types.String                              `tfsdk:"name"`
 	Description               types.String                              `tfsdk:"description"`
 	Tags                      types.Set                                 `tfsdk:"tags"`
 	Clone                     *VirtualMachineCloneModel                 `tfsdk:"clone"`
 	ISO                       *VirtualMachineIsoModel                   `tfsdk:"iso"`
+	Restore                   *VirtualMachineRestoreModel               `tfsdk:"restore"`
+	CloudImage                *VirtualMachineCloudImageModel            `tfsdk:"cloud_image"`
+	PXE                       *VirtualMachinePxeModel                   `tfsdk:"pxe"`
 	Agent                     *qt.VirtualMachineAgentModel              `tfsdk:"agent"`
 	BIOS                      types.String                              `tfsdk:"bios"`
 	CPU                       qt.VirtualMachineCpuModel                 `tfsdk:"cpu"`
 	Disks                     qt.VirtualMachineDiskSetValue             `tfsdk:"disks"`
 	ComputedDisks             qt.VirtualMachineDiskSetValue             `tfsdk:"computed_disks"`
+	EfiDisk                   *qt.VirtualMachineEfiDiskModel            `tfsdk:"efi_disk"`
+	TpmState                  *qt.VirtualMachineTpmStateModel           `tfsdk:"tpm_state"`
+	SerialDevices             []qt.VirtualMachineSerialDeviceModel      `tfsdk:"serial_devices"`
+	USBDevices                []qt.VirtualMachineUSBDeviceModel         `tfsdk:"usb_devices"`
+	Display                   *qt.VirtualMachineDisplayModel            `tfsdk:"display"`
+	Rng                       *qt.VirtualMachineRngModel                `tfsdk:"rng"`
+	Watchdog                  *qt.VirtualMachineWatchdogModel           `tfsdk:"watchdog"`
+	Audio                     *qt.VirtualMachineAudioModel              `tfsdk:"audio"`
 	PCIDevices                qt.VirtualMachinePCIDeviceSetValue        `tfsdk:"pci_devices"`
 	ComputedPCIDevices        qt.VirtualMachinePCIDeviceSetValue        `tfsdk:"computed_pci_devices"`
 	NetworkInterfaces         qt.VirtualMachineNetworkInterfaceSetValue `tfsdk:"network_interfaces"`
 	ComputedNetworkInterfaces qt.VirtualMachineNetworkInterfaceSetValue `tfsdk:"computed_network_interfaces"`
+	IPv4Addresses             types.List                                `tfsdk:"ipv4_addresses"`
+	IPv6Addresses             types.List                                `tfsdk:"ipv6_addresses"`
+	GuestNetworkInterfaces    types.List                                `tfsdk:"guest_network_interfaces"`
+	Hostname                  types.String                              `tfsdk:"hostname"`
+	OsInfo                    types.Object                              `tfsdk:"os_info"`
 	Memory                    qt.VirtualMachineMemoryModel              `tfsdk:"memory"`
 	MachineType               types.String                              `tfsdk:"machine_type"`
 	KVMArguments              types.String                              `tfsdk:"kvm_arguments"`
@@ -65,7 +124,14 @@ type VirtualMachineResourceModel struct {
 	Type                      types.String                              `tfsdk:"type"`
 	ResourcePool              types.String                              `tfsdk:"resource_pool"`
 	StartOnCreate             types.Bool                                `tfsdk:"start_on_create"`
+	Template                  types.Bool                                `tfsdk:"template"`
+	Migration                 *VirtualMachineMigrationModel             `tfsdk:"migration"`
+	Hotplug                   types.Set                                 `tfsdk:"hotplug"`
+	BootOrder                 types.List                                `tfsdk:"boot_order"`
 	StartOnNodeBoot           types.Bool                                `tfsdk:"start_on_node_boot"`
+	WaitForIP                 *VirtualMachineWaitForIpModel             `tfsdk:"wait_for_ip"`
+	StopStrategy              types.String                              `tfsdk:"stop_strategy"`
+	RebootOnPendingChanges    types.Bool                                `tfsdk:"reboot_on_pending_changes"`
 	Timeouts                  *VirtualMachineTerraformTimeouts          `tfsdk:"timeouts"`
 }
 
@@ -97,18 +163,111 @@ func VMToResourceModel(ctx context.Context, v *service.VirtualMachine, state *Vi
 		CloudInit:                 base.CloudInit,
 		Type:                      base.Type,
 		ResourcePool:              base.ResourcePool,
+		Hotplug:                   utils.UnpackSetType(v.Hotplug),
+		BootOrder:                 utils.UnpackListType(v.BootOrder),
 		StartOnNodeBoot:           base.StartOnNodeBoot,
+		Template:                  types.BoolValue(v.Template),
+		IPv4Addresses:             base.IPv4Addresses,
+		IPv6Addresses:             base.IPv6Addresses,
+		GuestNetworkInterfaces:    base.GuestNetworkInterfaces,
+		Hostname:                  base.Hostname,
+		OsInfo:                    base.OsInfo,
+	}
+
+	// firmware disks are only tracked once they are managed, so ones inherited from a clone are left alone
+	if state.EfiDisk != nil && v.EfiDisk != nil {
+		efi := qt.VMEfiDiskToModel(v.EfiDisk)
+		m.EfiDisk = &efi
+	}
+	if state.TpmState != nil && v.TpmState != nil {
+		tpm := qt.VMTpmStateToModel(v.TpmState)
+		m.TpmState = &tpm
+	}
+
+	// serial and usb devices, display, rng, watchdog and audio are tracked the same way, clones keep whatever the template had until they are managed
+	if state.SerialDevices != nil {
+		m.SerialDevices = base.SerialDevices
+	}
+	if state.USBDevices != nil {
+		m.USBDevices = base.USBDevices
+	}
+	if state.Display != nil {
+		m.Display = base.Display
+	}
+	if state.Rng != nil {
+		m.Rng = base.Rng
+	}
+	if state.Watchdog != nil {
+		m.Watchdog = base.Watchdog
+	}
+	if state.Audio != nil {
+		m.Audio = base.Audio
 	}
 
 	// carry over statemetadata
+	m.IDRange = state.IDRange
 	m.Clone = state.Clone
 	m.ISO = state.ISO
+	m.Restore = state.Restore
+	m.CloudImage = state.CloudImage
+	m.PXE = state.PXE
 	m.Timeouts = state.Timeouts
 	m.StartOnCreate = state.StartOnCreate
+	m.StopStrategy = state.StopStrategy
+	m.RebootOnPendingChanges = state.RebootOnPendingChanges
+	m.Migration = state.Migration
+	m.WaitForIP = state.WaitForIP
 
 	return m
 }
 
+// treats every attached device as terraform managed, except for unused and empty disks which
+// would otherwise be removed on the next apply
+func VMToImportedResourceModel(ctx context.Context, v *service.VirtualMachine) *VirtualMachineResourceModel {
+	managedDisks := []vm.VirtualMachineDisk{}
+	for _, disk := range v.Disks {
+		if disk.InterfaceType == "unused" || disk.Storage == "none" {
+			continue
+		}
+		managedDisks = append(managedDisks, disk)
+	}
+
+	state := &VirtualMachineResourceModel{
+		Disks:                  qt.VirtualMachineDiskToSetValue(ctx, managedDisks),
+		NetworkInterfaces:      qt.VirtualMachineNetworkInterfaceToSetValue(ctx, v.NetworkInterfaces),
+		PCIDevices:             qt.VirtualMachinePCIDeviceToSetValue(ctx, v.PCIDevices),
+		StartOnCreate:          types.BoolValue(true),
+		StopStrategy:           types.StringValue("shutdown_then_stop"),
+		RebootOnPendingChanges: types.BoolValue(false),
+	}
+	if v.EfiDisk != nil {
+		state.EfiDisk = &qt.VirtualMachineEfiDiskModel{}
+	}
+	if len(v.SerialDevices) > 0 {
+		state.SerialDevices = []qt.VirtualMachineSerialDeviceModel{}
+	}
+	if len(v.USBDevices) > 0 {
+		state.USBDevices = []qt.VirtualMachineUSBDeviceModel{}
+	}
+	if v.Display != nil {
+		state.Display = &qt.VirtualMachineDisplayModel{}
+	}
+	if v.Rng != nil {
+		state.Rng = &qt.VirtualMachineRngModel{}
+	}
+	if v.Watchdog != nil {
+		state.Watchdog = &qt.VirtualMachineWatchdogModel{}
+	}
+	if v.Audio != nil {
+		state.Audio = &qt.VirtualMachineAudioModel{}
+	}
+	if v.TpmState != nil {
+		state.TpmState = &qt.VirtualMachineTpmStateModel{}
+	}
+
+	return VMToResourceModel(ctx, v, state)
+}
+
 func sortComputedAndDefinedDisks(ctx context.Context, disks []vm.VirtualMachineDisk, state *qt.VirtualMachineDiskSetValue) ([]vm.VirtualMachineDisk, []vm.VirtualMachineDisk) {
 	definedDisksIface := []string{}
 	for _, disk := range state.Disks {
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/awlsring/proxmox-go/proxmox"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service"
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	qt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
 	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
 
 	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
@@ -13,67 +16,334 @@ import (
 	"github.com/r3labs/diff/v3"
 )
 
-var stateSensitiveProperties = []string{
+type changeClass int
+
+const (
+	changeNone changeClass = iota
+	changeHotpluggable
+	changeRebootRequired
+	changeStopRequired
+)
+
+func (c changeClass) String() string {
+	switch c {
+	case changeHotpluggable:
+		return "hotpluggable"
+	case changeRebootRequired:
+		return "reboot required"
+	case changeStopRequired:
+		return "stop required"
+	default:
+		return "none"
+	}
+}
+
+// properties that can only be changed while the virtual machine is powered off
+var stopRequiredProperties = []string{
 	"BIOS",
-	"CPU",
-	"Memory",
-	"Disks",
-	"NetworkInterfaces",
-	"PCIDevices",
-	"CloudInit",
 	"MachineType",
+	"PCIDevices",
+	"EfiDisk",
+	"TpmState",
+}
+
+// properties proxmox leaves pending on a running virtual machine until it is restarted
+var rebootRequiredProperties = []string{
+	"Agent",
 	"KVMArguments",
+	"KeyboardLayout",
+	"Type",
+	"Hotplug",
+	"BootOrder",
+	"SerialDevices",
+	"Display",
+	"Rng",
+	"Watchdog",
+	"Audio",
 }
 
-func isSensitivePropertyChanged(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) (bool, error) {
-	diff, err := diff.Diff(state, plan)
+// properties that are applied immediately, are only used by the provider, or are read back from proxmox
+var noRestartProperties = []string{
+	"ID",
+	"IDRange",
+	"Node",
+	"Name",
+	"Description",
+	"Tags",
+	"Clone",
+	"ISO",
+	"Restore",
+	"CloudImage",
+	"PXE",
+	"ComputedDisks",
+	"ComputedPCIDevices",
+	"ComputedNetworkInterfaces",
+	"IPv4Addresses",
+	"IPv6Addresses",
+	"GuestNetworkInterfaces",
+	"Hostname",
+	"OsInfo",
+	"ResourcePool",
+	"StartOnCreate",
+	"StartOnNodeBoot",
+	"Template",
+	"Migration",
+	"WaitForIP",
+	"StopStrategy",
+	"RebootOnPendingChanges",
+	"Timeouts",
+}
+
+// disk interfaces qemu can attach and detach while running
+var hotpluggableDiskInterfaces = []string{
+	"scsi",
+	"virtio",
+}
+
+func effectiveHotplug(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) []string {
+	current := vm.DefaultHotplug
+	if !state.Hotplug.IsNull() && !state.Hotplug.IsUnknown() {
+		current = utils.SetTypeToStringSlice(state.Hotplug)
+	}
+	planned := current
+	if !plan.Hotplug.IsNull() && !plan.Hotplug.IsUnknown() {
+		planned = utils.SetTypeToStringSlice(plan.Hotplug)
+	}
+
+	// qemu can only hotplug memory into numa nodes
+	numa := state.CPU.Numa.ValueBool() && plan.CPU.Numa.ValueBool()
+
+	// only features enabled both before and after the change can be relied on
+	features := []string{}
+	for _, f := range current {
+		if f == vm.HotplugMemory && !numa {
+			continue
+		}
+		if utils.ListContains(planned, f) {
+			features = append(features, f)
+		}
+	}
+	return features
+}
+
+func classifyDiskChanges(state []qt.VirtualMachineDiskModel, plan []qt.VirtualMachineDiskModel, hotplug []string) changeClass {
+	diskKey := func(d qt.VirtualMachineDiskModel) string {
+		return fmt.Sprintf("%s%v", d.InterfaceType.ValueString(), d.Position.ValueInt64())
+	}
+	attachable := func(d qt.VirtualMachineDiskModel) bool {
+		return utils.ListContains(hotplug, vm.HotplugDisk) && utils.ListContains(hotpluggableDiskInterfaces, d.InterfaceType.ValueString())
+	}
+
+	planned := map[string]qt.VirtualMachineDiskModel{}
+	for _, d := range plan {
+		planned[diskKey(d)] = d
+	}
+
+	class := changeNone
+	raise := func(c changeClass) {
+		if c > class {
+			class = c
+		}
+	}
+
+	for _, s := range state {
+		p, ok := planned[diskKey(s)]
+		if !ok {
+			if attachable(s) {
+				raise(changeHotpluggable)
+			} else {
+				raise(changeStopRequired)
+			}
+			continue
+		}
+		delete(planned, diskKey(s))
+
+		if !s.Storage.Equal(p.Storage) {
+			raise(changeStopRequired)
+			continue
+		}
+
+		// resizes are applied online, everything else is a drive option
+		p.Size = s.Size
+		p.Name = s.Name
+		d, err := diff.Diff(s, p)
+		if err != nil || len(d) > 0 {
+			raise(changeRebootRequired)
+		} else {
+			raise(changeHotpluggable)
+		}
+	}
+
+	for _, p := range planned {
+		if attachable(p) {
+			raise(changeHotpluggable)
+		} else {
+			raise(changeStopRequired)
+		}
+	}
+
+	return class
+}
+
+func classifyChange(change diff.Change, hotplug []string) changeClass {
+	field := change.Path[0]
+	sub := ""
+	if len(change.Path) > 1 {
+		sub = change.Path[1]
+	}
+
+	hotpluggableWith := func(feature string) changeClass {
+		if utils.ListContains(hotplug, feature) {
+			return changeHotpluggable
+		}
+		return changeRebootRequired
+	}
+
+	switch {
+	case utils.ListContains(stopRequiredProperties, field):
+		return changeStopRequired
+	case utils.ListContains(rebootRequiredProperties, field):
+		return changeRebootRequired
+	case field == "CPU":
+		switch sub {
+		case "CPUUnits", "CPULimit":
+			return changeHotpluggable
+		case "VCPUs":
+			return hotpluggableWith(vm.HotplugCPU)
+		}
+		return changeRebootRequired
+	case field == "Memory":
+		if sub == "Dedicated" {
+			return hotpluggableWith(vm.HotplugMemory)
+		}
+		if sub == "Hugepages" {
+			return changeRebootRequired
+		}
+		// balloon target and shares are adjusted live
+		return changeHotpluggable
+	case field == "NetworkInterfaces":
+		return hotpluggableWith(vm.HotplugNetwork)
+	case field == "USBDevices":
+		return hotpluggableWith(vm.HotplugUSB)
+	case field == "CloudInit":
+		return hotpluggableWith(vm.HotplugCloudInit)
+	case utils.ListContains(noRestartProperties, field):
+		return changeNone
+	}
+
+	// properties that aren't known to be safe are assumed to need the virtual machine powered off
+	return changeStopRequired
+}
+
+func classifyChanges(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) changeClass {
+	changes, err := diff.Diff(state, plan)
 	if err != nil {
-		tflog.Debug(ctx, "Error determining running state, defaulting to running")
-		return false, nil
+		tflog.Debug(ctx, "Error determining changed properties, assuming they need the virtual machine powered off")
+		return changeStopRequired
 	}
 
-	for _, d := range diff {
+	hotplug := effectiveHotplug(state, plan)
+	tflog.Debug(ctx, fmt.Sprintf("Effective hotplug features '%v'", hotplug))
+
+	class := changeNone
+	disksChecked := false
+	for _, d := range changes {
 		field := d.Path[0]
-		tflog.Debug(ctx, fmt.Sprintf("Checking property '%v'", field))
-		if utils.ListContains(stateSensitiveProperties, field) {
-			tflog.Debug(ctx, fmt.Sprintf("Property '%v' is state sensitive", field))
-			return true, nil
+		c := changeNone
+		if field == "Disks" {
+			if disksChecked {
+				continue
+			}
+			disksChecked = true
+			c = classifyDiskChanges(state.Disks.Disks, plan.Disks.Disks, hotplug)
+		} else {
+			c = classifyChange(d, hotplug)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Property '%v' change is %s", field, c))
+		if c > class {
+			class = c
 		}
 	}
-	return false, nil
+	return class
+}
+
+func isSensitivePropertyChanged(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) (bool, error) {
+	return classifyChanges(ctx, state, plan) == changeStopRequired, nil
+}
+
+func (r *virtualMachineResource) isRunning(ctx context.Context, node string, vmId int) (bool, error) {
+	status, err := r.client.GetVirtualMachineStatus(ctx, node, vmId)
+	if err != nil {
+		return false, err
+	}
+	return status.Status == proxmox.VIRTUALMACHINESTATUS_RUNNING, nil
 }
 
 func (r *virtualMachineResource) stopIfSensitivePropertyChanged(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) (bool, error) {
 	node := state.Node.ValueString()
 	vmId := int(state.ID.ValueInt64())
 
-	running := false
-	status, err := r.client.GetVirtualMachineStatus(ctx, node, vmId)
+	isSensitive, err := isSensitivePropertyChanged(ctx, state, plan)
 	if err != nil {
 		return false, err
 	}
-	if status.Status == proxmox.VIRTUALMACHINESTATUS_RUNNING {
-		running = true
+	if !isSensitive {
+		return false, nil
 	}
 
-	isSensitive, err := isSensitivePropertyChanged(ctx, state, plan)
+	running, err := r.isRunning(ctx, node, vmId)
 	if err != nil {
 		return false, err
 	}
 
-	if isSensitive {
-		if running {
-			tflog.Debug(ctx, "Property is state sensitive, stopping VM")
-			err = r.stopVm(ctx, node, vmId)
-			if err != nil {
-				return false, err
-			}
-			return true, nil
+	if running {
+		tflog.Debug(ctx, "Property requires the VM to be stopped, stopping VM")
+		err = r.stopVm(ctx, node, vmId, StopStrategy(plan.StopStrategy.ValueString()))
+		if err != nil {
+			return false, err
 		}
+		return true, nil
 	}
 	return false, nil
 }
 
+// applies changes proxmox left pending on a running virtual machine
+func (r *virtualMachineResource) rebootIfPendingChanges(ctx context.Context, state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) error {
+	node := plan.Node.ValueString()
+	vmId := int(state.ID.ValueInt64())
+
+	if !plan.RebootOnPendingChanges.ValueBool() || classifyChanges(ctx, state, plan) != changeRebootRequired {
+		return nil
+	}
+
+	running, err := r.isRunning(ctx, node, vmId)
+	if err != nil {
+		return err
+	}
+
+	if running {
+		tflog.Debug(ctx, "Changes are pending until restart, rebooting VM")
+		return r.rebootVm(ctx, node, vmId)
+	}
+	return nil
+}
+
+func (r *virtualMachineResource) rebootVm(ctx context.Context, node string, id int) error {
+	tflog.Debug(ctx, "Rebooting virtual machine")
+	upid, err := r.client.RebootVirtualMachine(ctx, node, id, r.timeouts.Reboot)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "waiting for reboot task "+upid)
+	err = r.client.WaitForTask(ctx, node, upid, r.timeouts.Reboot)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *virtualMachineResource) waitForStateChange(ctx context.Context, node string, vmId int, timeout int64, endState proxmox.VirtualMachineStatus) error {
 	tflog.Debug(ctx, "waiting for state change...")
 	deadline := setDeadline(timeout)
@@ -95,9 +365,31 @@ func (r *virtualMachineResource) waitForStateChange(ctx context.Context, node st
 	return nil
 }
 
+// returns the sid of the virtual machine when the ha manager enforces its state, a stop or start
+// done directly would be reverted by the ha manager
+func (r *virtualMachineResource) haManagedSid(ctx context.Context, id int) (string, error) {
+	haResource, err := r.client.DescribeVirtualMachineHAResource(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine if the virtual machine is HA managed: %w", err)
+	}
+	if haResource == nil || !haResource.IsManaged() {
+		return "", nil
+	}
+	return haResource.Sid, nil
+}
+
 func (r *virtualMachineResource) startVm(ctx context.Context, node string, id int) error {
-	tflog.Debug(ctx, "Starting virtual machine")
-	err := r.client.StartVirtualMachine(ctx, node, id)
+	sid, err := r.haManagedSid(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sid != "" {
+		tflog.Debug(ctx, "Starting HA managed virtual machine through HA state")
+		err = r.client.SetHAResourceState(ctx, sid, service.HAResourceStateStarted)
+	} else {
+		tflog.Debug(ctx, "Starting virtual machine")
+		err = r.client.StartVirtualMachine(ctx, node, id)
+	}
 	if err != nil {
 		return err
 	}
@@ -110,7 +402,85 @@ func (r *virtualMachineResource) startVm(ctx context.Context, node string, id in
 	return nil
 }
 
-func (r *virtualMachineResource) stopVm(ctx context.Context, node string, id int) error {
+type StopStrategy string
+
+const (
+	StopStrategyShutdown         StopStrategy = "shutdown"
+	StopStrategyStop             StopStrategy = "stop"
+	StopStrategyShutdownThenStop StopStrategy = "shutdown_then_stop"
+)
+
+func (r *virtualMachineResource) stopVm(ctx context.Context, node string, id int, strategy StopStrategy) error {
+	sid, err := r.haManagedSid(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sid != "" {
+		return r.haStopVm(ctx, node, id, sid)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Stopping virtual machine with strategy '%s'", strategy))
+	switch strategy {
+	case StopStrategyStop:
+		return r.hardStopVm(ctx, node, id)
+	case StopStrategyShutdown:
+		return r.shutdownVm(ctx, node, id)
+	default:
+		err := r.shutdownVm(ctx, node, id)
+		if err != nil {
+			tflog.Warn(ctx, "Shutdown failed, falling back to stop: "+err.Error())
+			return r.hardStopVm(ctx, node, id)
+		}
+		return nil
+	}
+}
+
+func (r *virtualMachineResource) shutdownVm(ctx context.Context, node string, id int) error {
+	tflog.Debug(ctx, "Shutting down virtual machine")
+	status, err := r.client.GetVirtualMachineStatus(ctx, node, id)
+	if err != nil {
+		return err
+	}
+
+	// with the agent enabled proxmox sends the shutdown through it, so make sure it will answer
+	if status.Agent != nil && *status.Agent == 1 {
+		tflog.Debug(ctx, "Agent is enabled, checking it is running")
+		err = r.client.PingVirtualMachineAgent(ctx, node, id)
+		if err != nil {
+			return fmt.Errorf("guest agent is enabled but not responding: %w", err)
+		}
+	}
+
+	err = r.client.ShutdownVirtualMachine(ctx, node, id, r.timeouts.Shutdown)
+	if err != nil {
+		return err
+	}
+
+	err = r.waitForStateChange(ctx, node, id, r.timeouts.Shutdown, proxmox.VIRTUALMACHINESTATUS_STOPPED)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// the ha manager shuts the virtual machine down and stops it once its own timeout passes
+func (r *virtualMachineResource) haStopVm(ctx context.Context, node string, id int, sid string) error {
+	tflog.Debug(ctx, "Stopping HA managed virtual machine through HA state")
+	err := r.client.SetHAResourceState(ctx, sid, service.HAResourceStateStopped)
+	if err != nil {
+		return err
+	}
+
+	err = r.waitForStateChange(ctx, node, id, r.timeouts.Shutdown+r.timeouts.Stop, proxmox.VIRTUALMACHINESTATUS_STOPPED)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *virtualMachineResource) hardStopVm(ctx context.Context, node string, id int) error {
 	tflog.Debug(ctx, "Stopping virtual machine")
 	err := r.client.StopVirtualMachine(ctx, node, id)
 	if err != nil {
@@ -0,0 +1,161 @@
+package vms
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/awlsring/terraform-provider-proxmox/internal/service/vm"
+	qt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/types"
+	vt "github.com/awlsring/terraform-provider-proxmox/proxmox/qemu/vms/types"
+	"github.com/awlsring/terraform-provider-proxmox/proxmox/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/r3labs/diff/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     []string
+		hotplug  []string
+		expected changeClass
+	}{
+		{"bios", []string{"BIOS"}, vm.DefaultHotplug, changeStopRequired},
+		{"pci devices", []string{"PCIDevices", "0"}, vm.DefaultHotplug, changeStopRequired},
+		{"kvm arguments", []string{"KVMArguments"}, vm.DefaultHotplug, changeRebootRequired},
+		{"rng", []string{"Rng", "Source"}, vm.DefaultHotplug, changeRebootRequired},
+		{"cpu units", []string{"CPU", "CPUUnits"}, []string{}, changeHotpluggable},
+		{"vcpus with cpu hotplug", []string{"CPU", "VCPUs"}, []string{vm.HotplugCPU}, changeHotpluggable},
+		{"vcpus without cpu hotplug", []string{"CPU", "VCPUs"}, vm.DefaultHotplug, changeRebootRequired},
+		{"cpu cores", []string{"CPU", "Cores"}, []string{vm.HotplugCPU}, changeRebootRequired},
+		{"memory with memory hotplug", []string{"Memory", "Dedicated"}, []string{vm.HotplugMemory}, changeHotpluggable},
+		{"memory without memory hotplug", []string{"Memory", "Dedicated"}, vm.DefaultHotplug, changeRebootRequired},
+		{"hugepages", []string{"Memory", "Hugepages"}, []string{vm.HotplugMemory}, changeRebootRequired},
+		{"balloon", []string{"Memory", "Floating"}, []string{}, changeHotpluggable},
+		{"network with network hotplug", []string{"NetworkInterfaces", "NetworkInterfaces"}, vm.DefaultHotplug, changeHotpluggable},
+		{"network without network hotplug", []string{"NetworkInterfaces", "NetworkInterfaces"}, []string{}, changeRebootRequired},
+		{"usb with usb hotplug", []string{"USBDevices", "0"}, vm.DefaultHotplug, changeHotpluggable},
+		{"cloud init without cloudinit hotplug", []string{"CloudInit", "User"}, vm.DefaultHotplug, changeRebootRequired},
+		{"agent", []string{"Agent", "Enabled"}, vm.DefaultHotplug, changeRebootRequired},
+		{"boot order", []string{"BootOrder"}, vm.DefaultHotplug, changeRebootRequired},
+		{"hotplug", []string{"Hotplug"}, vm.DefaultHotplug, changeRebootRequired},
+		{"description", []string{"Description"}, []string{}, changeNone},
+		{"computed disks", []string{"ComputedDisks", "Disks"}, []string{}, changeNone},
+		{"unknown property", []string{"Unknown"}, vm.DefaultHotplug, changeStopRequired},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, classifyChange(diff.Change{Type: diff.UPDATE, Path: test.path}, test.hotplug))
+		})
+	}
+}
+
+func testDisk(iface string, position int64, storage string, size int64) qt.VirtualMachineDiskModel {
+	return qt.VirtualMachineDiskModel{
+		Storage:       types.StringValue(storage),
+		FileFormat:    types.StringValue("raw"),
+		Size:          types.Int64Value(size),
+		UseIOThread:   types.BoolValue(false),
+		InterfaceType: types.StringValue(iface),
+		SSDEmulation:  types.BoolValue(false),
+		Position:      types.Int64Value(position),
+		Discard:       types.BoolValue(false),
+		Name:          types.StringValue(iface),
+	}
+}
+
+func TestClassifyDiskChanges(t *testing.T) {
+	scsi := testDisk("scsi", 0, "local-lvm", 20)
+	resized := testDisk("scsi", 0, "local-lvm", 40)
+	moved := testDisk("scsi", 0, "ceph", 20)
+	discard := testDisk("scsi", 0, "local-lvm", 20)
+	discard.Discard = types.BoolValue(true)
+	ide := testDisk("ide", 1, "local-lvm", 20)
+	extra := testDisk("scsi", 1, "local-lvm", 20)
+
+	tests := []struct {
+		name     string
+		state    []qt.VirtualMachineDiskModel
+		plan     []qt.VirtualMachineDiskModel
+		hotplug  []string
+		expected changeClass
+	}{
+		{"unchanged", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{scsi}, vm.DefaultHotplug, changeHotpluggable},
+		{"resize", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{resized}, vm.DefaultHotplug, changeHotpluggable},
+		{"drive option", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{discard}, vm.DefaultHotplug, changeRebootRequired},
+		{"storage moved", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{moved}, vm.DefaultHotplug, changeStopRequired},
+		{"scsi attached", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{scsi, extra}, vm.DefaultHotplug, changeHotpluggable},
+		{"scsi attached without disk hotplug", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{scsi, extra}, []string{vm.HotplugNetwork}, changeStopRequired},
+		{"ide attached", []qt.VirtualMachineDiskModel{scsi}, []qt.VirtualMachineDiskModel{scsi, ide}, vm.DefaultHotplug, changeStopRequired},
+		{"scsi detached", []qt.VirtualMachineDiskModel{scsi, extra}, []qt.VirtualMachineDiskModel{scsi}, vm.DefaultHotplug, changeHotpluggable},
+		{"ide detached", []qt.VirtualMachineDiskModel{scsi, ide}, []qt.VirtualMachineDiskModel{scsi}, vm.DefaultHotplug, changeStopRequired},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, classifyDiskChanges(test.state, test.plan, test.hotplug))
+		})
+	}
+}
+
+func TestClassifyChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel)
+		expected changeClass
+	}{
+		{"no change", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {}, changeNone},
+		{"cpu units", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.CPU.CPUUnits = types.Int64Value(200)
+		}, changeHotpluggable},
+		{"memory with memory hotplug disabled by the change", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.Memory.Dedicated = types.Int64Value(4096)
+			plan.Hotplug = utils.UnpackSetType([]string{vm.HotplugNetwork, vm.HotplugDisk})
+		}, changeRebootRequired},
+		{"memory with memory hotplug and numa", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.Memory.Dedicated = types.Int64Value(4096)
+		}, changeHotpluggable},
+		{"memory with memory hotplug without numa", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.Memory.Dedicated = types.Int64Value(4096)
+			plan.CPU.Numa = types.BoolValue(false)
+		}, changeRebootRequired},
+		{"memory with memory hotplug enabled by the change", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.Memory.Dedicated = types.Int64Value(4096)
+			state.Hotplug = utils.UnpackSetType([]string{vm.HotplugNetwork, vm.HotplugDisk})
+		}, changeRebootRequired},
+		{"kvm arguments", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.KVMArguments = types.StringValue("-no-reboot")
+		}, changeRebootRequired},
+		{"bios and cpu units", func(state *vt.VirtualMachineResourceModel, plan *vt.VirtualMachineResourceModel) {
+			plan.BIOS = types.StringValue("ovmf")
+			plan.CPU.CPUUnits = types.Int64Value(200)
+		}, changeStopRequired},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hotplug := utils.UnpackSetType([]string{vm.HotplugNetwork, vm.HotplugDisk, vm.HotplugMemory})
+			state := newTestModel("pve1")
+			state.CPU.Numa = types.BoolValue(true)
+			state.Hotplug = hotplug
+			plan := newTestModel("pve1")
+			plan.CPU.Numa = types.BoolValue(true)
+			plan.Hotplug = hotplug
+			test.change(state, plan)
+			assert.Equal(t, test.expected, classifyChanges(context.Background(), state, plan))
+		})
+	}
+}
+
+// every property has to be classified explicitly, anything else is assumed to need the virtual machine powered off
+func TestClassifyChangeCoversResourceModel(t *testing.T) {
+	handled := []string{"CPU", "Memory", "Disks", "NetworkInterfaces", "USBDevices", "CloudInit"}
+	model := reflect.TypeOf(vt.VirtualMachineResourceModel{})
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Field(i).Name
+		known := utils.ListContains(handled, field) ||
+			utils.ListContains(stopRequiredProperties, field) ||
+			utils.ListContains(rebootRequiredProperties, field) ||
+			utils.ListContains(noRestartProperties, field)
+		assert.True(t, known, "property %s is not classified", field)
+	}
+}
@@ -134,6 +134,17 @@ func Contains(s []int, e int) bool {
 	return false
 }
 
+// the timeout in seconds used when a resource has no default of its own
+const DefaultTimeout int64 = 600
+
+// returns the configured timeout in seconds, or the default when it is not set
+func LoadTimeout(t types.Int64, defaultTimeout int64) int64 {
+	if t.IsNull() || t.IsUnknown() {
+		return defaultTimeout
+	}
+	return t.ValueInt64()
+}
+
 func Float32ToInt64(f float32) int64 {
 	return int64(f)
 }